@@ -0,0 +1,94 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Next returns UUID following u, treating UUIDs as 128-bit big-endian
+// unsigned integers. Max is followed by Nil.
+func (u UUID) Next() UUID {
+	return u.Add(1)
+}
+
+// Prev returns UUID preceding u, treating UUIDs as 128-bit big-endian
+// unsigned integers. Nil is preceded by Max.
+func (u UUID) Prev() UUID {
+	hi, lo := u.ToUint128()
+	lo, borrow := bits.Sub64(lo, 1, 0)
+	hi, _ = bits.Sub64(hi, 0, borrow)
+	return FromUint64Pair(hi, lo)
+}
+
+// Add returns u increased by n, treating UUIDs as 128-bit big-endian
+// unsigned integers. The result wraps around past Max.
+func (u UUID) Add(n uint64) UUID {
+	hi, lo := u.ToUint128()
+	lo, carry := bits.Add64(lo, n, 0)
+	hi, _ = bits.Add64(hi, 0, carry)
+	return FromUint64Pair(hi, lo)
+}
+
+// Sub returns difference between u and other, treating UUIDs as 128-bit
+// big-endian unsigned integers. The result wraps around past Nil.
+func (u UUID) Sub(other UUID) UUID {
+	hi1, lo1 := u.ToUint128()
+	hi2, lo2 := other.ToUint128()
+	lo, borrow := bits.Sub64(lo1, lo2, 0)
+	hi, _ := bits.Sub64(hi1, hi2, borrow)
+	return FromUint64Pair(hi, lo)
+}
+
+// ToUint128 returns high and low 64 bits of UUID
+// as big-endian unsigned integers.
+func (u UUID) ToUint128() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+}
+
+// FromUint64Pair returns UUID made of high and low 64 bits
+// given as big-endian unsigned integers.
+func FromUint64Pair(hi, lo uint64) UUID {
+	u := UUID{}
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u
+}
+
+// BigInt returns UUID as a 128-bit big-endian unsigned integer.
+func (u UUID) BigInt() *big.Int {
+	return new(big.Int).SetBytes(u[:])
+}
+
+// FromBigInt returns UUID converted from unsigned integer n.
+// It will return error if n is negative or doesn't fit in 128 bits.
+func FromBigInt(n *big.Int) (UUID, error) {
+	if n.Sign() < 0 || n.BitLen() > Size*8 {
+		return Nil, fmt.Errorf("uuid: integer %s is out of UUID range", n)
+	}
+	u := UUID{}
+	n.FillBytes(u[:])
+	return u, nil
+}
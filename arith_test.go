@@ -0,0 +1,85 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestNext(t *testing.T) {
+	assert.Equal(t, MustFromString("00000000-0000-0000-0000-000000000001"), Nil.Next())
+	assert.Equal(t, MustFromString("00000000-0000-0001-0000-000000000000"), MustFromString("00000000-0000-0000-ffff-ffffffffffff").Next())
+	assert.Equal(t, Nil, Max.Next())
+}
+
+func TestPrev(t *testing.T) {
+	assert.Equal(t, Nil, MustFromString("00000000-0000-0000-0000-000000000001").Prev())
+	assert.Equal(t, MustFromString("00000000-0000-0000-ffff-ffffffffffff"), MustFromString("00000000-0000-0001-0000-000000000000").Prev())
+	assert.Equal(t, Max, Nil.Prev())
+}
+
+func TestAdd(t *testing.T) {
+	u := MustFromString("00000000-0000-0000-ffff-ffffffffff00")
+	assert.Equal(t, MustFromString("00000000-0000-0001-0000-0000000000ff"), u.Add(0x1ff))
+	assert.Equal(t, u, u.Add(0))
+	assert.Equal(t, MustFromString("00000000-0000-0000-0000-0000000000fe"), Max.Add(0xff))
+}
+
+func TestSub(t *testing.T) {
+	u1 := MustFromString("00000000-0000-0001-0000-0000000000ff")
+	u2 := MustFromString("00000000-0000-0000-ffff-ffffffffff00")
+	assert.Equal(t, MustFromString("00000000-0000-0000-0000-0000000001ff"), u1.Sub(u2))
+	assert.Equal(t, Nil, u1.Sub(u1))
+	assert.Equal(t, Max, Nil.Sub(Nil.Next()))
+	assert.Equal(t, u1, u2.Add(0x1ff))
+}
+
+func TestUint128(t *testing.T) {
+	hi, lo := NamespaceDNS.ToUint128()
+	assert.Equal(t, uint64(0x6ba7b8109dad11d1), hi)
+	assert.Equal(t, uint64(0x80b400c04fd430c8), lo)
+	assert.Equal(t, NamespaceDNS, FromUint64Pair(hi, lo))
+}
+
+func TestBigInt(t *testing.T) {
+	n, ok := new(big.Int).SetString("143098242404177361603877621312831893704", 10)
+	require.True(t, ok)
+	assert.Equal(t, n, NamespaceDNS.BigInt())
+
+	u, err := FromBigInt(n)
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u)
+
+	u, err = FromBigInt(big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, Nil.Next(), u)
+
+	_, err = FromBigInt(big.NewInt(-1))
+	assert.Error(t, err)
+
+	_, err = FromBigInt(new(big.Int).Lsh(big.NewInt(1), 128))
+	assert.Error(t, err)
+}
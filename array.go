@@ -0,0 +1,98 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// UUIDArray can be used with the standard sql package to represent
+// a Postgres uuid[] array, e.g. as an argument of "id = ANY($1)" query.
+type UUIDArray []UUID
+
+// Value implements the driver.Valuer interface.
+// It returns Postgres array literal, like "{6ba7b810-...,6ba7b811-...}",
+// or nil for nil array.
+func (a UUIDArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	if len(a) == 0 {
+		return "{}", nil
+	}
+
+	buf := make([]byte, 1+len(a)*37)
+	buf[0] = '{'
+	for i, u := range a {
+		encodeCanonical(buf[1+i*37:], u)
+		buf[(i+1)*37] = ','
+	}
+	buf[len(buf)-1] = '}'
+	return string(buf), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts Postgres array literal given as a string or a byte slice.
+// Elements may be quoted, but NULL elements aren't supported.
+func (a *UUIDArray) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return a.parse(src)
+	case string:
+		return a.parse([]byte(src))
+	default:
+		return fmt.Errorf("uuid: cannot convert %T to UUIDArray", src)
+	}
+}
+
+// Parses Postgres array literal.
+func (a *UUIDArray) parse(src []byte) error {
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return fmt.Errorf("uuid: incorrect array literal: %s", errorInput(src))
+	}
+	body := src[1 : len(src)-1]
+	if len(body) == 0 {
+		*a = UUIDArray{}
+		return nil
+	}
+
+	elems := bytes.Split(body, []byte{','})
+	res := make(UUIDArray, len(elems))
+	for i, elem := range elems {
+		elem = bytes.TrimSpace(elem)
+		if len(elem) >= 2 && elem[0] == '"' && elem[len(elem)-1] == '"' {
+			elem = elem[1 : len(elem)-1]
+		} else if bytes.EqualFold(elem, []byte("NULL")) {
+			return fmt.Errorf("uuid: NULL element at index %d of array literal", i)
+		}
+		if err := res[i].UnmarshalText(elem); err != nil {
+			return fmt.Errorf("uuid: failed to parse array element at index %d: %w", i, err)
+		}
+	}
+	*a = res
+	return nil
+}
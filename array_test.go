@@ -0,0 +1,89 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestUUIDArrayValue(t *testing.T) {
+	v, err := UUIDArray{NamespaceDNS, NamespaceURL}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8,6ba7b811-9dad-11d1-80b4-00c04fd430c8}", v)
+
+	v, err = UUIDArray{NamespaceDNS}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", v)
+
+	v, err = UUIDArray{}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", v)
+
+	v, err = UUIDArray(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestUUIDArrayScan(t *testing.T) {
+	a := UUIDArray{}
+	require.NoError(t, a.Scan("{6ba7b810-9dad-11d1-80b4-00c04fd430c8,6ba7b811-9dad-11d1-80b4-00c04fd430c8}"))
+	assert.Equal(t, UUIDArray{NamespaceDNS, NamespaceURL}, a)
+
+	require.NoError(t, a.Scan([]byte(`{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 6ba7b811-9dad-11d1-80b4-00c04fd430c8}`)))
+	assert.Equal(t, UUIDArray{NamespaceDNS, NamespaceURL}, a)
+
+	require.NoError(t, a.Scan("{}"))
+	assert.Equal(t, UUIDArray{}, a)
+
+	require.NoError(t, a.Scan(nil))
+	assert.Nil(t, a)
+
+	v, err := UUIDArray{NamespaceOID, NamespaceX500}.Value()
+	require.NoError(t, err)
+	require.NoError(t, a.Scan(v))
+	assert.Equal(t, UUIDArray{NamespaceOID, NamespaceX500}, a)
+}
+
+func TestUUIDArrayScanInvalid(t *testing.T) {
+	tests := []interface{}{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8,NULL}",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8,}",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430cz}",
+		42,
+	}
+	for _, src := range tests {
+		a := UUIDArray{}
+		assert.Error(t, a.Scan(src), src)
+	}
+}
+
+func BenchmarkUUIDArrayValue(b *testing.B) {
+	a := make(UUIDArray, 100)
+	for i := 0; i < b.N; i++ {
+		_, _ = a.Value()
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import "fmt"
+
+// Avro binary encoding of uuid logical type: string length as zig-zag
+// encoded varint, followed by canonical string representation of UUID.
+const avroLengthPrefix = 36 << 1
+
+// MarshalAvro returns UUID encoded in Avro binary encoding of uuid
+// logical type, which annotates string type.
+func (u UUID) MarshalAvro() ([]byte, error) {
+	data := make([]byte, 37)
+	data[0] = avroLengthPrefix
+	encodeCanonical(data[1:], u)
+	return data, nil
+}
+
+// UnmarshalAvro decodes UUID from Avro binary encoding of uuid logical
+// type. String is expected in a form accepted by UnmarshalText.
+func (u *UUID) UnmarshalAvro(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("uuid: empty Avro value")
+	}
+
+	// All supported text forms are shorter than 64 bytes,
+	// so length fits in a single varint byte.
+	if data[0]&0x80 != 0 || data[0]&0x01 != 0 {
+		return fmt.Errorf("uuid: incorrect Avro string length")
+	}
+	n := int(data[0] >> 1)
+	if len(data)-1 != n {
+		return fmt.Errorf("uuid: Avro string length %d doesn't match %d bytes of data", n, len(data)-1)
+	}
+	return u.UnmarshalText(data[1:])
+}
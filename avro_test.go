@@ -0,0 +1,57 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestMarshalAvro(t *testing.T) {
+	data, err := NamespaceDNS.MarshalAvro()
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0x48}, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"...), data)
+
+	var u UUID
+	require.NoError(t, u.UnmarshalAvro(data))
+	assert.Equal(t, NamespaceDNS, u)
+}
+
+func TestUnmarshalAvro(t *testing.T) {
+	s := "6ba7b8109dad11d180b400c04fd430c8"
+	var u UUID
+	require.NoError(t, u.UnmarshalAvro(append([]byte{byte(len(s) << 1)}, s...)))
+	assert.Equal(t, NamespaceDNS, u)
+
+	tests := [][]byte{
+		nil,
+		{0x01},
+		{0x80, 0x01},
+		append([]byte{0x48}, s...),
+		append([]byte{byte(len(s) << 1)}, "6ba7b8109dad11d180b400c04fd430cx"...),
+	}
+	for _, data := range tests {
+		assert.Error(t, u.UnmarshalAvro(data), data)
+	}
+}
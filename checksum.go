@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"fmt"
+)
+
+// ChecksumGenerator provides interface for generating UUIDs carrying
+// a checksum, see NewV8Checksum.
+// Generators returned by NewGenerator implement it.
+type ChecksumGenerator interface {
+	NewV8Checksum() (UUID, error)
+}
+
+// CRC-8 lookup table for polynomial x^8 + x^2 + x + 1.
+var crc8Table = func() (table [256]byte) {
+	for i := range table {
+		crc := byte(i)
+		for j := 0; j < 8; j++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return
+}()
+
+// Returns CRC-8 of data.
+func crc8(data []byte) byte {
+	var crc byte
+	for _, b := range data {
+		crc = crc8Table[crc^b]
+	}
+	return crc
+}
+
+// NewV8Checksum returns version 8 UUID laid out as version 7 one, with
+// millisecond timestamp followed by random data, except that its last
+// byte holds CRC-8 of the preceding 15 bytes. It is meant for IDs typed
+// by humans, such as support references, where VerifyChecksum catches
+// transcription errors, including any single mistyped or two swapped
+// adjacent hex digits, without a database lookup.
+func NewV8Checksum() (UUID, error) {
+	return global().NewV8Checksum()
+}
+
+// NewV8Checksum returns version 8 UUID carrying checksum,
+// see package-level NewV8Checksum.
+func (g *rfc4122Generator) NewV8Checksum() (UUID, error) {
+	u := UUID{}
+	timeNow, err := g.getV7Time()
+	if err != nil {
+		return Nil, err
+	}
+	putUint48(u[:6], timeNow)
+
+	if err := g.readFull(u[6:15]); err != nil {
+		return Nil, fmt.Errorf("failed to generate random data for UUID V8: %w", err)
+	}
+
+	u = finalizeUUID(u, V8)
+	u[15] = crc8(u[:15])
+	g.countGenerated(V8, 1)
+
+	return u, nil
+}
+
+// VerifyChecksum returns true if u is a version 8 UUID whose last byte
+// matches the checksum of the rest, as generated by NewV8Checksum.
+func VerifyChecksum(u UUID) bool {
+	return u.Version() == V8 && u.Variant() == VariantRFC4122 && crc8(u[:15]) == u[15]
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestCRC8(t *testing.T) {
+	// Check value of CRC-8 with polynomial 0x07.
+	assert.Equal(t, byte(0xf4), crc8([]byte("123456789")))
+	assert.Equal(t, byte(0), crc8(nil))
+}
+
+func TestNewV8Checksum(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClock(&fakeClock{now: start}))
+
+	u, err := g.(ChecksumGenerator).NewV8Checksum()
+	require.NoError(t, err)
+	assert.Equal(t, V8, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+	assert.True(t, VerifyChecksum(u))
+	assert.Equal(t, uint64(start.UnixMilli()), uint64(u[0])<<40|uint64(u[1])<<32|uint64(u[2])<<24|uint64(u[3])<<16|uint64(u[4])<<8|uint64(u[5]))
+
+	u2, err := NewV8Checksum()
+	require.NoError(t, err)
+	assert.True(t, VerifyChecksum(u2))
+	assert.NotEqual(t, u, u2)
+}
+
+func TestNewV8ChecksumFaultyRand(t *testing.T) {
+	g := NewGenerator(WithRandReader(&faultyReader{}))
+	u, err := g.(ChecksumGenerator).NewV8Checksum()
+	assert.Error(t, err)
+	assert.Equal(t, Nil, u)
+}
+
+func TestVerifyChecksumTypos(t *testing.T) {
+	u := Must(NewV8Checksum())
+	s := u.String()
+
+	// Any single mistyped hex digit is detected.
+	for i := 0; i < len(s); i++ {
+		if s[i] == '-' {
+			continue
+		}
+		for _, c := range hexDigits {
+			if byte(c) == s[i] {
+				continue
+			}
+			typo := s[:i] + string(c) + s[i+1:]
+			assert.False(t, VerifyChecksum(Must(FromString(typo))), typo)
+		}
+	}
+
+	// Any two swapped adjacent hex digits are detected.
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == '-' || s[i+1] == '-' || s[i] == s[i+1] {
+			continue
+		}
+		typo := s[:i] + string(s[i+1]) + string(s[i]) + s[i+2:]
+		assert.False(t, VerifyChecksum(Must(FromString(typo))), typo)
+	}
+}
+
+func TestVerifyChecksumOtherVersions(t *testing.T) {
+	assert.False(t, VerifyChecksum(Nil))
+	assert.False(t, VerifyChecksum(Must(NewV7())))
+
+	// Checksum matches, but version doesn't.
+	u := Must(NewV4())
+	u[15] = crc8(u[:15])
+	assert.False(t, VerifyChecksum(u))
+}
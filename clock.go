@@ -0,0 +1,224 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Maximal number of clock shards. Each doubling of shards halves the
+// number of clock sequence values available to a shard for UUIDs
+// generated within the same clock tick.
+const maxClockShards = 8
+
+// Number of high bits of 14-bit clock sequence identifying the process
+// when WithProcessClockSequence option is used.
+const processClockBits = 8
+
+// clockShard holds state of time-based UUIDs generation. Every shard of
+// a generator produces clock sequence values with distinct low bits, so
+// shards never produce the same UUID for the same timestamp.
+type clockShard struct {
+	mu            sync.Mutex
+	lastTime      uint64
+	clockSequence uint16
+
+	// Prevents false sharing of adjacent shards.
+	_ [40]byte
+}
+
+// WithClockShards splits state of time-based (version 1, 2 and 6) UUIDs
+// generation into n independently locked shards, so that generation
+// scales with the number of goroutines running in parallel. The low bits
+// of clock sequence identify the shard, leaving fewer values for UUIDs
+// generated by a shard within the same clock tick. n is capped at 8.
+// The global generator uses as many shards as GOMAXPROCS allows.
+func WithClockShards(n int) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.clockShardCount = min(max(n, 1), maxClockShards)
+	}
+}
+
+// WithProcessClockSequence makes generator put the low 8 bits of the
+// process ID, mixed with a hash of instanceID unless it's empty, into
+// the high bits of the initial clock sequence of time-based UUIDs. The
+// remaining 6 bits are still random, as RFC 4122 recommends, so that
+// a restarted process doesn't repeat the clock sequence. Processes on
+// the same host share the node ID, so this keeps apart processes whose
+// PIDs differ in the low 8 bits, given the same instanceID, instead of
+// relying on random clock sequences not to collide. Use instanceID,
+// e.g. a port or a container name, where processes may share the PID,
+// as in containers. Different instance IDs may still map to the same
+// bits, so this is no guarantee of uniqueness, and the process bits
+// change once the clock sequence is advanced many times within a tick.
+func WithProcessClockSequence(instanceID string) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		tag := uint16(os.Getpid())
+		if instanceID != "" {
+			h := fnv.New32a()
+			h.Write([]byte(instanceID))
+			tag ^= uint16(h.Sum32())
+		}
+		g.clockProcess = true
+		g.clockProcessTag = tag & (1<<processClockBits - 1)
+	}
+}
+
+// Clock provides current time to generator. Implementations may also
+// implement Sleeper to control waiting of generator for the clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// Sleeper is implemented by clocks controlling how generator waits,
+// e.g. simulated clocks advancing their time instead of blocking.
+type Sleeper interface {
+	Sleep(d time.Duration)
+}
+
+// WithClock makes generator read time of time-based UUIDs from c
+// instead of the system clock, so that tests and simulations can fully
+// control it. If c implements Sleeper, generator waits with its Sleep,
+// as when stalling with ClockRegressionStall policy. Time elapsed for
+// WithMonotonicClockV7 is measured by c as well.
+func WithClock(c Clock) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.epochFunc = c.Now
+		g.sleepFunc = time.Sleep
+		if s, ok := c.(Sleeper); ok {
+			g.sleepFunc = s.Sleep
+		}
+		if !g.v7ClockStart.IsZero() {
+			g.v7ClockStart = c.Now()
+		}
+	}
+}
+
+// Returns options applied to the global generator by default.
+func defaultGlobalOptions() []GeneratorOption {
+	return []GeneratorOption{WithClockShards(runtime.GOMAXPROCS(0))}
+}
+
+// Initializes clock shards with random clock sequence.
+func (g *rfc4122Generator) initClockShards() error {
+	n := max(g.clockShardCount, 1)
+	shardBits := bits.Len(uint(n - 1))
+	g.clockSeqMask = 0xffff << shardBits
+	g.clockShards = make([]clockShard, n)
+	g.clockShardPool.New = func() any {
+		i := g.clockShardNext.Add(1)
+		return &g.clockShards[i%uint32(len(g.clockShards))]
+	}
+
+	clockSeq, err := g.initialClockSequence()
+	if err != nil {
+		return err
+	}
+	for i := range g.clockShards {
+		g.clockShards[i].clockSequence = clockSeq&g.clockSeqMask | uint16(i)
+	}
+	return nil
+}
+
+// Returns clock shard to be used by the calling goroutine.
+// Shards are cached per processor by sync.Pool.
+func (g *rfc4122Generator) getClockShard() *clockShard {
+	if len(g.clockShards) == 1 {
+		return &g.clockShards[0]
+	}
+	return g.clockShardPool.Get().(*clockShard)
+}
+
+// Returns clock shard to the pool.
+func (g *rfc4122Generator) putClockShard(shard *clockShard) {
+	if len(g.clockShards) > 1 {
+		g.clockShardPool.Put(shard)
+	}
+}
+
+// Returns clock sequence following clockSeq within the same shard.
+func (g *rfc4122Generator) nextClockSequence(clockSeq uint16) uint16 {
+	step := ^g.clockSeqMask + 1
+	return clockSeq + step
+}
+
+// Returns initial clock sequence, random except for the process bits
+// if WithProcessClockSequence option is used.
+func (g *rfc4122Generator) initialClockSequence() (uint16, error) {
+	seq, err := g.randomClockSequence()
+	if err != nil || !g.clockProcess {
+		return seq, err
+	}
+	const shift = 14 - processClockBits
+	return g.clockProcessTag<<shift | seq&(1<<shift-1), nil
+}
+
+// Returns random clock sequence.
+func (g *rfc4122Generator) randomClockSequence() (uint16, error) {
+	buf := make([]byte, 2)
+	if err := g.readFull(buf); err != nil {
+		return 0, fmt.Errorf("failed to read random data for clock sequence: %w", err)
+	}
+	return binary.BigEndian.Uint16(buf), nil
+}
+
+// ClockEvent describes a time-based UUID generated when the clock hasn't
+// advanced past the timestamp of the previous one, either because it
+// moved backwards, e.g. stepped by NTP, or because it still reads the
+// same clock tick. Generator then changes the clock sequence or applies
+// its ClockRegressionPolicy to keep UUIDs unique.
+type ClockEvent struct {
+	// Previous is timestamp of the previously generated UUID.
+	Previous time.Time
+	// Current is time read from the clock.
+	Current time.Time
+	// OldClockSequence is clock sequence of the previously generated UUID.
+	OldClockSequence uint16
+	// NewClockSequence is clock sequence used from now on. It equals
+	// OldClockSequence if generator returned an error.
+	NewClockSequence uint16
+}
+
+// Regressed returns true if the clock moved backwards, as opposed to
+// having not advanced past the previous clock tick.
+func (e ClockEvent) Regressed() bool {
+	return e.Current.Before(e.Previous)
+}
+
+// WithClockObserver makes generator of time-based (version 1, 2 and 6)
+// UUIDs call observer on every ClockEvent, so that clock problems are
+// surfaced rather than silently absorbed by the clock sequence. Bursts
+// of UUIDs within one clock tick report events too, so observer must
+// be cheap, and use Regressed to tell them from clock regressions.
+// The observer must be safe for concurrent use.
+func WithClockObserver(observer func(ClockEvent)) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.clockObserver = observer
+	}
+}
@@ -0,0 +1,430 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestWithClockShards(t *testing.T) {
+	g := newRFC4122Generator()
+	WithClockShards(4)(g)
+	require.NoError(t, g.initClockShards())
+	require.Len(t, g.clockShards, 4)
+
+	for i := range g.clockShards {
+		assert.Equal(t, uint16(i), g.clockShards[i].clockSequence&^g.clockSeqMask)
+	}
+
+	WithClockShards(100)(g)
+	assert.Equal(t, maxClockShards, g.clockShardCount)
+
+	WithClockShards(0)(g)
+	assert.Equal(t, 1, g.clockShardCount)
+}
+
+func TestWithProcessClockSequence(t *testing.T) {
+	clockSeq := func(fill byte, opts ...GeneratorOption) uint16 {
+		g := newRFC4122Generator()
+		g.rand = bytes.NewReader(bytes.Repeat([]byte{fill}, 2))
+		for _, opt := range opts {
+			opt(g)
+		}
+		require.NoError(t, g.initClockShards())
+		return g.clockShards[0].clockSequence
+	}
+
+	seq := clockSeq(0x00, WithProcessClockSequence(""))
+	assert.Equal(t, uint16(os.Getpid())&0xff<<6, seq)
+	assert.NotEqual(t, seq, clockSeq(0x00, WithProcessClockSequence("8080")))
+
+	// Low bits stay random, e.g. after restart with the same PID.
+	restarted := clockSeq(0xff, WithProcessClockSequence(""))
+	assert.Equal(t, seq|0x3f, restarted)
+
+	g := newRFC4122Generator()
+	g.rand = &faultyReader{}
+	WithProcessClockSequence("8080")(g)
+	require.Error(t, g.initClockShards())
+
+	// Shard bits are still applied.
+	g = newRFC4122Generator()
+	g.rand = bytes.NewReader(make([]byte, 2))
+	WithProcessClockSequence("")(g)
+	WithClockShards(4)(g)
+	require.NoError(t, g.initClockShards())
+	for i := range g.clockShards {
+		assert.Equal(t, seq|uint16(i), g.clockShards[i].clockSequence)
+	}
+}
+
+func TestClockShardSequence(t *testing.T) {
+	now := time.Now()
+	g := newRFC4122Generator()
+	g.epochFunc = func() time.Time { return now }
+	WithClockShards(4)(g)
+
+	seen := make(map[uint16]bool)
+	for i := 0; i < 100; i++ {
+		u, err := g.NewV1()
+		require.NoError(t, err)
+		clockSeq := binary.BigEndian.Uint16(u[8:]) & 0x3fff
+		assert.False(t, seen[clockSeq])
+		seen[clockSeq] = true
+	}
+}
+
+func TestClockShardsParallel(t *testing.T) {
+	now := time.Now()
+	g := newRFC4122Generator()
+	g.epochFunc = func() time.Time { return now }
+	WithClockShards(8)(g)
+
+	const goroutines, perGoroutine = 8, 200
+	results := make([][]UUID, goroutines)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				u, err := g.NewV1()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[i] = append(results[i], u)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	ids := NewSet()
+	for _, r := range results {
+		ids.Add(r...)
+	}
+	assert.Equal(t, goroutines*perGoroutine, ids.Len())
+}
+
+func BenchmarkNewV1Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = NewV1()
+		}
+	})
+}
+
+func BenchmarkNewV1ParallelSingleShard(b *testing.B) {
+	g := NewGenerator()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = g.NewV1()
+		}
+	})
+}
+
+func BenchmarkNewV6Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = NewV6()
+		}
+	})
+}
+
+func BenchmarkNewV7Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = NewV7()
+		}
+	})
+}
+
+// Simulated clock, advancing only when asked to sleep.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.slept = append(c.slept, d)
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{now: start}
+	g := NewGenerator(WithClock(c))
+
+	for _, gen := range []func() (UUID, error){g.NewV1, g.NewV6, g.NewV7} {
+		u, err := gen()
+		require.NoError(t, err)
+		ts, err := timeOf(u)
+		require.NoError(t, err)
+		assert.True(t, start.Equal(ts), u.String())
+	}
+}
+
+func TestWithClockSleeper(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{now: start}
+	g := NewGenerator(WithClock(c), WithClockRegressionPolicy(ClockRegressionStall))
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+
+	// Stalling waits for the simulated clock instead of blocking.
+	c.set(start.Add(-time.Second))
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second}, c.slept)
+	assert.Equal(t, timestampV1(u1), timestampV1(u2))
+}
+
+func TestClockRegressionStallLimit(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{now: start}
+	g := NewGenerator(WithClock(c), WithClockRegressionPolicy(ClockRegressionStall))
+
+	_, err := g.NewV1()
+	require.NoError(t, err)
+
+	// A clock further behind than the limit isn't waited for.
+	c.set(start.Add(-time.Hour))
+	_, err = g.NewV1()
+	require.ErrorIs(t, err, ErrClockRegression)
+	assert.Empty(t, c.slept)
+
+	// Neither is a clock failing to catch up within the limit.
+	gen := newClockGenerator(ClockRegressionStall, start, start.Add(-time.Millisecond))
+	var slept []time.Duration
+	gen.sleepFunc = func(d time.Duration) { slept = append(slept, d) }
+	_, err = gen.NewV1()
+	require.NoError(t, err)
+	_, err = gen.NewV1()
+	require.ErrorIs(t, err, ErrClockRegression)
+	assert.Len(t, slept, int(maxClockStall/time.Millisecond))
+}
+
+func TestWithClockMonotonicV7(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for name, opts := range map[string][]GeneratorOption{
+		"clock first": {WithClock(&fakeClock{now: start}), WithMonotonicClockV7()},
+		"clock last":  {WithMonotonicClockV7(), WithClock(&fakeClock{now: start})},
+	} {
+		g := NewGenerator(opts...)
+		u, err := g.NewV7()
+		require.NoError(t, err)
+		ts, err := TimestampFromV7(u)
+		require.NoError(t, err)
+		assert.True(t, start.Equal(ts), name)
+	}
+}
+
+func BenchmarkNewV4Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = NewV4()
+		}
+	})
+}
+
+func BenchmarkNewV7MonotonicParallel(b *testing.B) {
+	g := NewGenerator(WithMonotonicV7())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = g.NewV7()
+		}
+	})
+}
+
+// Returns number of mutex contention events recorded by the runtime
+// in stacks passing through each function of the package.
+func mutexContention() map[string]int64 {
+	records := make([]runtime.BlockProfileRecord, 64)
+	for {
+		n, ok := runtime.MutexProfile(records)
+		if ok {
+			records = records[:n]
+			break
+		}
+		records = make([]runtime.BlockProfileRecord, n+64)
+	}
+
+	res := make(map[string]int64)
+	for _, r := range records {
+		frames := runtime.CallersFrames(r.Stack())
+		for {
+			frame, more := frames.Next()
+			if name, ok := strings.CutPrefix(frame.Function, "github.com/satori/go.uuid."); ok {
+				res[name] += r.Count
+			}
+			if !more {
+				break
+			}
+		}
+	}
+	return res
+}
+
+func TestGlobalGeneratorContention(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping contention report in short mode")
+	}
+	// Goroutines must run in parallel to contend, even on a single CPU.
+	procs := max(runtime.GOMAXPROCS(0), 4)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+	defer runtime.SetMutexProfileFraction(runtime.SetMutexProfileFraction(1))
+	before := mutexContention()
+
+	g := NewGenerator(WithMonotonicV7(), WithClockShards(procs))
+	var wg sync.WaitGroup
+	for i := 0; i < 4*procs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				_, _ = NewV1()
+				_, _ = g.NewV1()
+				_, _ = NewV4()
+				_, _ = g.NewV4()
+				_, _ = NewV7()
+				_, _ = g.NewV7()
+			}
+		}()
+	}
+	wg.Wait()
+
+	after := mutexContention()
+	for name, count := range after {
+		if delta := count - before[name]; delta > 0 {
+			t.Logf("mutex contention in %s: %d events", name, delta)
+		}
+	}
+	// Random and version 7 UUIDs are generated without locks.
+	for _, name := range []string{"(*rfc4122Generator).NewV4", "(*rfc4122Generator).NewV7"} {
+		assert.Equal(t, before[name], after[name], name)
+	}
+}
+
+func TestWithClockObserver(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &fakeClock{now: start}
+	var events []ClockEvent
+	g := NewGenerator(WithClock(c), WithClockObserver(func(e ClockEvent) {
+		events = append(events, e)
+	}))
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// Same clock tick.
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.False(t, events[0].Regressed())
+	assert.True(t, start.Equal(events[0].Previous))
+	assert.True(t, start.Equal(events[0].Current))
+	assert.Equal(t, clockSequenceOf(u1)&0x3fff, events[0].OldClockSequence)
+	assert.Equal(t, clockSequenceOf(u2)&0x3fff, events[0].NewClockSequence)
+	assert.NotEqual(t, events[0].OldClockSequence, events[0].NewClockSequence)
+
+	// Clock stepped back.
+	c.set(start.Add(-time.Second))
+	u3, err := g.NewV6()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Regressed())
+	assert.True(t, start.Add(-time.Second).Equal(events[1].Current))
+	assert.Equal(t, clockSequenceOf(u2)&0x3fff, events[1].OldClockSequence)
+	assert.Equal(t, clockSequenceOf(u3)&0x3fff, events[1].NewClockSequence)
+	assert.Equal(t, uint64(1), g.(StateReporter).State().ClockRegressions)
+
+	// Clock advanced.
+	c.set(start.Add(time.Second))
+	_, err = g.NewV1()
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestWithClockObserverPolicies(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for policy, sameSeq := range map[ClockRegressionPolicy]bool{
+		ClockRegressionError: true,
+		ClockRegressionStall: false, // Stalled until the same tick.
+	} {
+		c := &fakeClock{now: start}
+		var events []ClockEvent
+		g := NewGenerator(WithClock(c), WithClockRegressionPolicy(policy), WithClockObserver(func(e ClockEvent) {
+			events = append(events, e)
+		}))
+
+		_, err := g.NewV1()
+		require.NoError(t, err)
+		c.set(start.Add(-time.Second))
+		_, _ = g.NewV1()
+
+		require.Len(t, events, 1)
+		assert.True(t, events[0].Regressed())
+		assert.Equal(t, sameSeq, events[0].OldClockSequence == events[0].NewClockSequence)
+	}
+}
+
+func TestWithClockObserverReentrant(t *testing.T) {
+	var g Generator
+	calls := 0
+	g = NewGenerator(WithClock(&fakeClock{now: time.Now()}), WithClockObserver(func(ClockEvent) {
+		if calls++; calls == 1 {
+			_, _ = g.NewV1()
+		}
+	}))
+	_, _ = g.NewV1()
+	_, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
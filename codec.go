@@ -22,9 +22,9 @@
 package uuid
 
 import (
-	"bytes"
-	"encoding/hex"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 // FromBytes returns UUID converted from raw byte slice input.
@@ -37,6 +37,16 @@ func FromBytes(input []byte) (u UUID, err error) {
 	return
 }
 
+// FromBytesAt returns UUID read from the 16 bytes of buf starting at offset.
+// It will return error if buf doesn't hold 16 bytes at offset.
+func FromBytesAt(buf []byte, offset int) (u UUID, err error) {
+	if offset < 0 || offset > len(buf)-Size {
+		return Nil, fmt.Errorf("uuid: expected %d bytes at offset %d, got %d bytes buffer", Size, offset, len(buf))
+	}
+	copy(u[:], buf[offset:offset+Size])
+	return
+}
+
 // FromBytesOrNil returns UUID converted from raw byte slice input.
 // Same behavior as FromBytes, but returns a Nil UUID on error.
 func FromBytesOrNil(input []byte) UUID {
@@ -52,11 +62,47 @@ func FromBytesOrNil(input []byte) UUID {
 func FromString(input string) (u UUID, err error) {
 	err = u.UnmarshalText([]byte(input))
 	if err != nil {
-		return Nil, fmt.Errorf("uuid: failed to parse UUID from string: %s", input)
+		return Nil, err
+	}
+	return
+}
+
+// Parse returns UUID parsed from text input given either as a string or
+// as a byte slice. Input is expected in a form accepted by UnmarshalText.
+// Unlike Scan, Parse never treats 16 bytes of input as binary UUID;
+// use FromBytes for that.
+func Parse[T string | []byte](input T) (u UUID, err error) {
+	err = u.UnmarshalText([]byte(input))
+	if err != nil {
+		return Nil, err
 	}
 	return
 }
 
+// ParseBytes returns UUID parsed from text input held in a byte slice,
+// e.g. a path segment handed by an HTTP router, without copying it.
+// It's the same as Parse, for callers not using generics.
+func ParseBytes(input []byte) (UUID, error) {
+	return Parse(input)
+}
+
+// FromStringCanonical returns UUID parsed from string input.
+// Input is expected in a form accepted by UnmarshalTextStrict.
+func FromStringCanonical(input string) (u UUID, err error) {
+	err = u.UnmarshalTextStrict([]byte(input))
+	if err != nil {
+		return Nil, err
+	}
+	return
+}
+
+// FromStringLenient returns UUID parsed from string input, tolerating
+// surrounding whitespace and quotes often found in CSV files and configs.
+// After trimming, input is expected in a form accepted by UnmarshalText.
+func FromStringLenient(input string) (UUID, error) {
+	return FromString(strings.ToLower(strings.Trim(input, " \t\r\n\"'`")))
+}
+
 // FromStringOrNil returns UUID parsed from string input.
 // Same behavior as FromString, but returns a Nil UUID on error.
 func FromStringOrNil(input string) UUID {
@@ -67,13 +113,78 @@ func FromStringOrNil(input string) UUID {
 	return uuid
 }
 
+// ParseSlice returns UUIDs parsed from slice of strings.
+// Each input is expected in a form accepted by UnmarshalText.
+// Parsing stops at the first invalid input and the returned error
+// reports its index.
+func ParseSlice(inputs []string) ([]UUID, error) {
+	res := make([]UUID, len(inputs))
+	for i, input := range inputs {
+		if err := res[i].UnmarshalText([]byte(input)); err != nil {
+			return nil, fmt.Errorf("uuid: failed to parse UUID at index %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// ParseBytesSlice returns UUIDs parsed from slice of text byte slices.
+// Same behavior as ParseSlice.
+func ParseBytesSlice(inputs [][]byte) ([]UUID, error) {
+	res := make([]UUID, len(inputs))
+	for i, input := range inputs {
+		if err := res[i].UnmarshalText(input); err != nil {
+			return nil, fmt.Errorf("uuid: failed to parse UUID at index %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// MustParseSlice returns UUIDs parsed from slice of strings.
+// Same behavior as ParseSlice, but panics on error.
+func MustParseSlice(inputs []string) []UUID {
+	res, err := ParseSlice(inputs)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// EncodeCanonical writes canonical string representation of UUID,
+// as returned by String, into dst and returns the number of bytes
+// written, which is always 36. It panics if dst is shorter.
+func EncodeCanonical(dst []byte, u UUID) int {
+	encodeCanonical(dst[:36], u)
+	return 36
+}
+
+// DecodeCanonical decodes UUID from its 36 bytes canonical string
+// representation in src into dst. Hexadecimal digits are accepted
+// in any case.
+func DecodeCanonical(dst *UUID, src []byte) error {
+	if len(src) != 36 {
+		return newLengthError(src, expectCanonicalLen)
+	}
+	return dst.decodeCanonical(src, 0)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 // The encoding is the same as returned by String.
 func (u UUID) MarshalText() (text []byte, err error) {
-	text = []byte(u.String())
+	text = make([]byte, 36)
+	encodeCanonical(text, u)
 	return
 }
 
+// MarshalJSON implements the json.Marshaler interface.
+// The encoding is the same as returned by String, quoted.
+// It spares encoding/json the extra allocations of MarshalText.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	data := make([]byte, 38)
+	data[0], data[37] = '"', '"'
+	encodeCanonical(data[1:37], u)
+	return data, nil
+}
+
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 // Following formats are supported:
 //
@@ -81,6 +192,8 @@ func (u UUID) MarshalText() (text []byte, err error) {
 //	"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
 //	"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"
 //	"6ba7b8109dad11d180b400c04fd430c8"
+//	"{6ba7b8109dad11d180b400c04fd430c8}"
+//	"urn:uuid:6ba7b8109dad11d180b400c04fd430c8"
 //
 // ABNF for supported UUID text representation follows:
 //
@@ -103,36 +216,62 @@ func (u UUID) MarshalText() (text []byte, err error) {
 func (u *UUID) UnmarshalText(text []byte) (err error) {
 	switch len(text) {
 	case 32:
-		return u.decodeHashLike(text)
+		return u.decodeHashLike(text, 0)
 	case 36:
-		return u.decodeCanonical(text)
-	case 38:
+		return u.decodeCanonical(text, 0)
+	case 34, 38:
 		return u.decodeBraced(text)
-	case 41:
-		fallthrough
-	case 45:
+	case 41, 45:
 		return u.decodeURN(text)
 	default:
-		return fmt.Errorf("uuid: incorrect UUID length: %s", text)
+		return newLengthError(text, expectTextLength)
 	}
 }
 
-// decodeCanonical decodes UUID string in format
-// "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
-func (u *UUID) decodeCanonical(t []byte) (err error) {
-	if t[8] != '-' || t[13] != '-' || t[18] != '-' || t[23] != '-' {
-		return fmt.Errorf("uuid: incorrect UUID format %s", string(t))
+// UnmarshalTextStrict decodes UUID only from its canonical lowercase form
+// "6ba7b810-9dad-11d1-80b4-00c04fd430c8", as returned by String.
+// Use it where an API contract allows no other form.
+func (u *UUID) UnmarshalTextStrict(text []byte) error {
+	if len(text) != 36 {
+		return newLengthError(text, expectCanonicalLen)
+	}
+
+	// Report whichever comes first: an uppercase digit
+	// or a malformed canonical form.
+	var v UUID
+	err := v.decodeCanonical(text, 0)
+	limit := len(text)
+	if pe, ok := err.(*ParseError); ok {
+		limit = pe.Offset
+	}
+	for i, c := range text[:limit] {
+		if 'A' <= c && c <= 'F' {
+			return newParseError(text, i, expectLowerHexDigit)
+		}
+	}
+	if err != nil {
+		return err
 	}
+	*u = v
+	return nil
+}
 
+// decodeCanonical decodes UUID string in format
+// "6ba7b810-9dad-11d1-80b4-00c04fd430c8" found in text at offset start.
+func (u *UUID) decodeCanonical(text []byte, start int) (err error) {
 	dst := u[:]
+	pos := start
 	for i, byteGroup := range byteGroups {
 		if i > 0 {
-			t = t[1:] // skip dash
+			if text[pos] != '-' {
+				return newParseError(text, pos, expectDash)
+			}
+			pos++
 		}
-		if _, err := hex.Decode(dst[:byteGroup/2], t[:byteGroup]); err != nil {
+		if err := decodeHex(dst[:byteGroup/2], text, pos); err != nil {
 			return err
 		}
-		t = t[byteGroup:]
+		pos += byteGroup
 		dst = dst[byteGroup/2:]
 	}
 
@@ -140,53 +279,118 @@ func (u *UUID) decodeCanonical(t []byte) (err error) {
 }
 
 // decodeHashLike decodes UUID string in format
-// "6ba7b8109dad11d180b400c04fd430c8".
-func (u *UUID) decodeHashLike(t []byte) (err error) {
-	if _, err = hex.Decode(u[:], t); err != nil {
-		return fmt.Errorf("uuid: failed to decode hash-like format: %s", string(t))
-	}
-	return nil
+// "6ba7b8109dad11d180b400c04fd430c8" found in text at offset start.
+func (u *UUID) decodeHashLike(text []byte, start int) (err error) {
+	return decodeHex(u[:], text, start)
 }
 
 // decodeBraced decodes UUID string in format
 // "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}" or in format
 // "{6ba7b8109dad11d180b400c04fd430c8}".
-func (u *UUID) decodeBraced(t []byte) (err error) {
-	if len(t) < 2 || t[0] != '{' || t[len(t)-1] != '}' {
-		return fmt.Errorf("uuid: incorrect UUID format %s", string(t))
+func (u *UUID) decodeBraced(text []byte) (err error) {
+	if text[0] != '{' {
+		return newParseError(text, 0, expectOpenBrace)
 	}
-	return u.decodePlain(t[1 : len(t)-1])
+	// Both braces are checked before u is written.
+	last := len(text) - 1
+	if text[last] != '}' {
+		return newParseError(text, last, expectCloseBrace)
+	}
+	return u.decodePlain(text, 1, last)
 }
 
 // decodeURN decodes UUID string in format
 // "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8" or in format
 // "urn:uuid:6ba7b8109dad11d180b400c04fd430c8".
-func (u *UUID) decodeURN(t []byte) (err error) {
-	if len(t) < 9 || !bytes.Equal(t[:9], urnPrefix) {
-		return fmt.Errorf("uuid: incorrect URN format: %s", string(t))
+func (u *UUID) decodeURN(text []byte) (err error) {
+	for i, c := range urnPrefix {
+		if text[i] != c {
+			return newParseError(text, i, expectURNPrefix)
+		}
 	}
-	return u.decodePlain(t[9:])
+	return u.decodePlain(text, len(urnPrefix), len(text))
 }
 
 // decodePlain decodes UUID string in canonical format
 // "6ba7b810-9dad-11d1-80b4-00c04fd430c8" or in hash-like format
-// "6ba7b8109dad11d180b400c04fd430c8".
-func (u *UUID) decodePlain(t []byte) (err error) {
-	switch len(t) {
+// "6ba7b8109dad11d180b400c04fd430c8" found in text[start:end].
+func (u *UUID) decodePlain(text []byte, start, end int) (err error) {
+	switch end - start {
 	case 32:
-		return u.decodeHashLike(t)
+		return u.decodeHashLike(text, start)
 	case 36:
-		return u.decodeCanonical(t)
+		return u.decodeCanonical(text, start)
 	default:
-		return fmt.Errorf("uuid: incorrect UUID length: %s", string(t))
+		return newLengthError(text, expectTextLength)
 	}
 }
 
+// decodeHex decodes len(dst) bytes from hex digits in text at offset
+// pos, reporting the position of the first invalid digit in text.
+func decodeHex(dst, text []byte, pos int) error {
+	for i := range dst {
+		hi, ok := fromHexChar(text[pos])
+		if !ok {
+			return newParseError(text, pos, expectHexDigit)
+		}
+		lo, ok := fromHexChar(text[pos+1])
+		if !ok {
+			return newParseError(text, pos+1, expectHexDigit)
+		}
+		dst[i] = hi<<4 | lo
+		pos += 2
+	}
+	return nil
+}
+
+// fromHexChar converts a hex character into its value.
+func fromHexChar(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (u UUID) MarshalBinary() (data []byte, err error) {
 	return u.Bytes(), nil
 }
 
+// AppendBytes appends the 16 raw bytes of UUID to dst and
+// returns the extended buffer.
+func (u UUID) AppendBytes(dst []byte) []byte {
+	return append(dst, u[:]...)
+}
+
+// EncodeSlice appends raw bytes of UUIDs of src to dst, 16 bytes per
+// UUID with no separators, and returns the extended buffer. Combined
+// with DecodeSlice, it's meant for snapshotting large sets of UUIDs
+// to disk or cache into reused buffers.
+func EncodeSlice(dst []byte, src []UUID) []byte {
+	dst = slices.Grow(dst, len(src)*Size)
+	for _, u := range src {
+		dst = append(dst, u[:]...)
+	}
+	return dst
+}
+
+// DecodeSlice decodes UUIDs packed by EncodeSlice in src into dst.
+// It will return error if src isn't exactly 16 bytes per element of dst.
+func DecodeSlice(dst []UUID, src []byte) error {
+	if len(src) != len(dst)*Size {
+		return fmt.Errorf("uuid: expected %d bytes for %d UUIDs, got %d bytes", len(dst)*Size, len(dst), len(src))
+	}
+	for i := range dst {
+		copy(dst[i][:], src[i*Size:])
+	}
+	return nil
+}
+
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 // It will return error if the slice isn't 16 bytes long.
 func (u *UUID) UnmarshalBinary(data []byte) (err error) {
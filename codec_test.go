@@ -22,10 +22,12 @@
 package uuid
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
 )
 
 func TestFromBytes(t *testing.T) {
@@ -41,6 +43,65 @@ func TestFromBytes(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestFromBytesAt(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	buf := append([]byte{0x01, 0x02, 0x03}, u[:]...)
+	buf = append(buf, 0xff)
+
+	u1, err := FromBytesAt(buf, 3)
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+
+	_, err = FromBytesAt(buf, 5)
+	assert.Error(t, err)
+
+	_, err = FromBytesAt(buf, -1)
+	assert.Error(t, err)
+}
+
+func TestAppendBytes(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	prefix := []byte{0x01, 0x02}
+
+	b := u.AppendBytes(prefix)
+	assert.Equal(t, append([]byte{0x01, 0x02}, u[:]...), b)
+}
+
+func TestEncodeSlice(t *testing.T) {
+	src := []UUID{NamespaceDNS, NamespaceURL, Max}
+	data := EncodeSlice([]byte{0x01}, src)
+	require.Len(t, data, 1+3*Size)
+	assert.Equal(t, byte(0x01), data[0])
+	assert.Equal(t, NamespaceURL.Bytes(), data[1+Size:1+2*Size])
+
+	dst := make([]UUID, len(src))
+	require.NoError(t, DecodeSlice(dst, data[1:]))
+	assert.Equal(t, src, dst)
+
+	assert.Empty(t, EncodeSlice(nil, nil))
+	assert.NoError(t, DecodeSlice(nil, nil))
+	assert.Error(t, DecodeSlice(dst, data))
+	assert.Error(t, DecodeSlice(dst[:2], data[1:]))
+}
+
+func BenchmarkEncodeSlice(b *testing.B) {
+	src := make([]UUID, 1000)
+	buf := make([]byte, 0, len(src)*Size)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = EncodeSlice(buf[:0], src)
+	}
+}
+
+func BenchmarkDecodeSlice(b *testing.B) {
+	data := make([]byte, 1000*Size)
+	dst := make([]UUID, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = DecodeSlice(dst, data)
+	}
+}
+
 func BenchmarkFromBytes(b *testing.B) {
 	bytes := []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	for i := 0; i < b.N; i++ {
@@ -48,6 +109,94 @@ func BenchmarkFromBytes(b *testing.B) {
 	}
 }
 
+func TestParseSlice(t *testing.T) {
+	inputs := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b811-9dad-11d1-80b4-00c04fd430c8}",
+	}
+
+	ids, err := ParseSlice(inputs)
+	require.NoError(t, err)
+	assert.Equal(t, []UUID{NamespaceDNS, NamespaceURL}, ids)
+
+	_, err = ParseSlice(append(inputs, "invalid"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index 2")
+
+	ids, err = ParseSlice(nil)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestParseBytesSlice(t *testing.T) {
+	inputs := [][]byte{
+		[]byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+		[]byte("invalid"),
+	}
+
+	ids, err := ParseBytesSlice(inputs[:1])
+	require.NoError(t, err)
+	assert.Equal(t, []UUID{NamespaceDNS}, ids)
+
+	_, err = ParseBytesSlice(inputs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index 1")
+}
+
+func TestMustParseSlice(t *testing.T) {
+	assert.Equal(t, []UUID{NamespaceDNS}, MustParseSlice([]string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}))
+	assert.Panics(t, func() {
+		MustParseSlice([]string{"invalid"})
+	})
+}
+
+func BenchmarkParseSlice(b *testing.B) {
+	inputs := make([]string, 100)
+	for i := range inputs {
+		inputs[i] = Must(NewV4()).String()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseSlice(inputs)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for _, input := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		u, err := ParseBytes([]byte(input))
+		require.NoError(t, err)
+		assert.Equal(t, NamespaceDNS, u)
+	}
+
+	// Raw bytes aren't accepted.
+	_, err := ParseBytes(NamespaceDNS.Bytes())
+	assert.Error(t, err)
+
+	var pe *ParseError
+	_, err = ParseBytes([]byte("6ba7b810-9dad-11d1-80b4-00c04fd430cx"))
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 35, pe.Offset)
+
+	input := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ParseBytes(input)
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	input := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBytes(input)
+	}
+}
+
 func TestMarshalBinary(t *testing.T) {
 	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	b1 := []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
@@ -65,6 +214,15 @@ func BenchmarkMarshalBinary(b *testing.B) {
 	}
 }
 
+func BenchmarkUnmarshalBinary(b *testing.B) {
+	data := NamespaceDNS.Bytes()
+	u := UUID{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = u.UnmarshalBinary(data)
+	}
+}
+
 func TestUnmarshalBinary(t *testing.T) {
 	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	b1 := []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
@@ -92,6 +250,8 @@ func TestFromString(t *testing.T) {
 		{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", u},
 		{"6ba7b8109dad11d180b400c04fd430c8", u},
 		{"urn:uuid:6ba7b8109dad11d180b400c04fd430c8", u},
+		{"{6ba7b8109dad11d180b400c04fd430c8}", u},
+		{"{6BA7B8109DAD11D180B400C04FD430C8}", u},
 	}
 
 	for _, tt := range tests {
@@ -165,6 +325,10 @@ func TestFromStringInvalid(t *testing.T) {
 		"6ba7b810+9dad+11d1+80b4+00c04fd430c8",
 		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
 		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8>",
+		"(6ba7b8109dad11d180b400c04fd430c8}",
+		"{6ba7b8109dad11d180b400c04fd430c8>",
+		"{6ba7b8109dad11d180b400c04fd430cz}",
+		"6ba7b8109dad11d180b400c04fd430c8{}",
 		"zba7b810-9dad-11d1-80b4-00c04fd430c8",
 		"6ba7b810-9dad11d180b400c04fd430c8",
 		"6ba7b8109dad-11d180b400c04fd430c8",
@@ -203,11 +367,62 @@ func TestMarshalText(t *testing.T) {
 func BenchmarkMarshalText(b *testing.B) {
 	u, err := NewV4()
 	require.NoError(b, err)
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		_, _ = u.MarshalText()
 	}
 }
 
+func TestEncodeCanonical(t *testing.T) {
+	buf := make([]byte, 40)
+	n := EncodeCanonical(buf[2:], NamespaceDNS)
+	assert.Equal(t, 36, n)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", string(buf[2:2+n]))
+
+	assert.Panics(t, func() {
+		EncodeCanonical(make([]byte, 35), NamespaceDNS)
+	})
+}
+
+func TestDecodeCanonical(t *testing.T) {
+	var u UUID
+	err := DecodeCanonical(&u, []byte("6BA7B810-9DAD-11D1-80B4-00C04FD430C8"))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u)
+
+	err = DecodeCanonical(&u, []byte("6ba7b8109dad11d180b400c04fd430c8"))
+	assert.Error(t, err)
+
+	err = DecodeCanonical(&u, []byte("6ba7b810-9dad-11d1-80b4+00c04fd430c8"))
+	assert.Error(t, err)
+}
+
+func TestMarshalJSON(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	b1, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(b1))
+
+	b2, err := json.Marshal(struct{ IDs []UUID }{[]UUID{u, Nil}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"IDs":["6ba7b810-9dad-11d1-80b4-00c04fd430c8","00000000-0000-0000-0000-000000000000"]}`, string(b2))
+
+	var u1 UUID
+	err = json.Unmarshal(b1, &u1)
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	u, err := NewV4()
+	require.NoError(b, err)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = json.Marshal(u)
+	}
+}
+
 func TestUnmarshalText(t *testing.T) {
 	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	b1 := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
@@ -221,6 +436,94 @@ func TestUnmarshalText(t *testing.T) {
 	u2 := UUID{}
 	err = u2.UnmarshalText(b2)
 	assert.Error(t, err)
+
+	// Unmatched brace is rejected without overwriting u.
+	for _, input := range []string{
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+		"{6ba7b8109dad11d180b400c04fd430c8]",
+	} {
+		u3 := NamespaceURL
+		var perr *ParseError
+		err = u3.UnmarshalText([]byte(input))
+		require.True(t, errors.As(err, &perr), input)
+		assert.Equal(t, len(input)-1, perr.Offset, input)
+		assert.Equal(t, NamespaceURL, u3, input)
+	}
+}
+
+func TestUnmarshalTextStrict(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	u1 := UUID{}
+	err := u1.UnmarshalTextStrict([]byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+
+	tests := []string{
+		"",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4_00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+	}
+	for _, input := range tests {
+		u2 := UUID{}
+		err = u2.UnmarshalTextStrict([]byte(input))
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParse(t *testing.T) {
+	u1, err := Parse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u1)
+
+	u2, err := Parse([]byte("{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u2)
+
+	_, err = Parse("")
+	assert.Error(t, err)
+
+	_, err = Parse(NamespaceDNS.Bytes())
+	assert.Error(t, err)
+}
+
+func BenchmarkParse(b *testing.B) {
+	s := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	for i := 0; i < b.N; i++ {
+		_, _ = Parse(s)
+	}
+}
+
+func TestFromStringLenient(t *testing.T) {
+	tests := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"  6ba7b810-9dad-11d1-80b4-00c04fd430c8\t",
+		"\"6BA7B810-9DAD-11D1-80B4-00C04FD430C8\"",
+		" '{6ba7b810-9dad-11d1-80b4-00c04fd430c8}'\r\n",
+		"`urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8`",
+	}
+	for _, input := range tests {
+		u, err := FromStringLenient(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, NamespaceDNS, u)
+	}
+
+	_, err := FromStringLenient(" \"6ba7b810-9dad-11d1-80b4\" ")
+	assert.Error(t, err)
+}
+
+func TestFromStringCanonical(t *testing.T) {
+	u1, err := FromStringCanonical("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u1)
+
+	u2, err := FromStringCanonical("{6ba7b810-9dad-11d1-80b4-00c04fd430c8}")
+	assert.Error(t, err)
+	assert.Equal(t, Nil, u2)
 }
 
 func BenchmarkUnmarshalText(b *testing.B) {
@@ -236,6 +539,7 @@ var sink string
 func BenchmarkMarshalToString(b *testing.B) {
 	u, err := NewV4()
 	require.NoError(b, err)
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
 		sink = u.String()
 	}
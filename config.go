@@ -0,0 +1,71 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// Package path used to recognize types decoded by DecodeHook.
+var pkgPath = reflect.TypeOf(UUID{}).PkgPath()
+
+// DecodeHook returns decode hook for config loaders built on
+// github.com/mitchellh/mapstructure, such as viper, converting strings
+// and byte slices into UUID and other types of this package which
+// implement encoding.TextUnmarshaler, e.g. NullUUID and V7UUID.
+// Values of other types pass through unchanged. Its signature matches
+// mapstructure.DecodeHookFuncType, so it can be used without importing
+// this package into mapstructure or vice versa:
+//
+//	viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		uuid.DecodeHook(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//	)))
+//
+// Loaders relying on encoding.TextUnmarshaler, such as TOML decoders
+// and envconfig, need no hook.
+func DecodeHook() func(from, to reflect.Type, data interface{}) (interface{}, error) {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if to.PkgPath() != pkgPath {
+			return data, nil
+		}
+		var text []byte
+		switch data := data.(type) {
+		case string:
+			text = []byte(data)
+		case []byte:
+			text = data
+		default:
+			return data, nil
+		}
+		v := reflect.New(to)
+		tu, ok := v.Interface().(encoding.TextUnmarshaler)
+		if !ok {
+			return data, nil
+		}
+		if err := tu.UnmarshalText(text); err != nil {
+			return nil, err
+		}
+		return v.Elem().Interface(), nil
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestDecodeHook(t *testing.T) {
+	hook := DecodeHook()
+	stringType := reflect.TypeOf("")
+
+	v, err := hook(stringType, reflect.TypeOf(UUID{}), NamespaceDNS.String())
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, v)
+
+	v, err = hook(reflect.TypeOf([]byte(nil)), reflect.TypeOf(UUID{}), []byte(NamespaceURL.String()))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceURL, v)
+
+	v, err = hook(stringType, reflect.TypeOf(NullUUID{}), "")
+	require.NoError(t, err)
+	assert.Equal(t, NullUUID{}, v)
+
+	v, err = hook(stringType, reflect.TypeOf(NullUUID{}), NamespaceDNS.String())
+	require.NoError(t, err)
+	assert.Equal(t, NullUUID{UUID: NamespaceDNS, Valid: true}, v)
+
+	v7 := Must(NewV7())
+	v, err = hook(stringType, reflect.TypeOf(V7UUID{}), v7.String())
+	require.NoError(t, err)
+	assert.Equal(t, V7UUID(v7), v)
+
+	_, err = hook(stringType, reflect.TypeOf(V7UUID{}), NamespaceDNS.String())
+	assert.Error(t, err)
+
+	_, err = hook(stringType, reflect.TypeOf(UUID{}), "6ba7b810-9dad-11d1-80b4-00c04fd430cx")
+	var pe *ParseError
+	assert.True(t, errors.As(err, &pe))
+}
+
+func TestDecodeHookPassThrough(t *testing.T) {
+	hook := DecodeHook()
+
+	// Other types are left to other hooks.
+	v, err := hook(reflect.TypeOf(""), reflect.TypeOf(""), "value")
+	require.NoError(t, err)
+	assert.Equal(t, "value", v)
+
+	v, err = hook(reflect.TypeOf(0), reflect.TypeOf(UUID{}), 42)
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+
+	// Types of the package without text representation.
+	v, err = hook(reflect.TypeOf(""), reflect.TypeOf(FormatCanonical), "canonical")
+	require.NoError(t, err)
+	assert.Equal(t, "canonical", v)
+}
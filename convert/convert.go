@@ -0,0 +1,189 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package convert provides best-effort conversions between UUIDs and
+// other popular identifier formats: KSUID, XID and Snowflake IDs.
+//
+// Identifiers are mapped onto version 7 UUID layout, so that converted
+// values keep the time ordering of originals. Conversions which keep all
+// the bits of original identifier have a reverse counterpart.
+package convert
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// KSUID epoch is 14e8 seconds after Unix epoch.
+const ksuidEpoch = 1400000000
+
+// Sizes of binary identifiers.
+const (
+	ksuidSize = 20
+	xidSize   = 12
+)
+
+// Snowflake layout: 41 bits of time, 10 bits of worker ID and 12 bits
+// of sequence number.
+const (
+	snowflakeTimeBits   = 41
+	snowflakeWorkerBits = 10
+	snowflakeSeqBits    = 12
+)
+
+// FromKSUID returns version 7 UUID converted from 20-byte binary KSUID.
+// The conversion keeps timestamp and the first 74 bits of payload, so
+// it can't be reversed.
+func FromKSUID(id []byte) (uuid.UUID, error) {
+	if len(id) != ksuidSize {
+		return uuid.Nil, fmt.Errorf("convert: expected %d bytes KSUID, got %d bytes", ksuidSize, len(id))
+	}
+
+	u := uuid.UUID{}
+	ts := uint64(binary.BigEndian.Uint32(id[0:4])) + ksuidEpoch
+	putUint48(u[0:6], ts*1000)
+	copy(u[6:], id[4:])
+
+	return finalize(u), nil
+}
+
+// FromXID returns version 7 UUID converted from 12-byte binary XID.
+// Machine ID, process ID and counter of XID are stored in random
+// bits of UUID, so the conversion can be reversed with ToXID.
+func FromXID(id []byte) (uuid.UUID, error) {
+	if len(id) != xidSize {
+		return uuid.Nil, fmt.Errorf("convert: expected %d bytes XID, got %d bytes", xidSize, len(id))
+	}
+
+	u := uuid.UUID{}
+	ts := uint64(binary.BigEndian.Uint32(id[0:4]))
+	putUint48(u[0:6], ts*1000)
+	u[7] = id[4]
+	copy(u[9:], id[5:])
+
+	return finalize(u), nil
+}
+
+// ToXID returns 12-byte binary XID converted back from UUID
+// returned by FromXID.
+func ToXID(u uuid.UUID) ([]byte, error) {
+	if err := checkV7(u); err != nil {
+		return nil, err
+	}
+	ms := uint48(u[0:6])
+	if ms%1000 != 0 || ms/1000 > 0xffffffff || u[6]&0x0f != 0 || u[8]&0x3f != 0 {
+		return nil, fmt.Errorf("convert: UUID %s wasn't converted from XID", u)
+	}
+
+	id := make([]byte, xidSize)
+	binary.BigEndian.PutUint32(id[0:4], uint32(ms/1000))
+	id[4] = u[7]
+	copy(id[5:], u[9:])
+	return id, nil
+}
+
+// FromSnowflake returns version 7 UUID converted from Snowflake ID
+// counting time since epoch. The sequence number is stored in rand_a
+// field and the worker ID at the start of rand_b field of UUID, so the
+// conversion can be reversed with ToSnowflake. It will return error
+// if epoch is before Unix epoch or time of id is out of range of 48-bit
+// timestamp of UUID.
+func FromSnowflake(id int64, epoch time.Time) (uuid.UUID, error) {
+	if id < 0 {
+		return uuid.Nil, fmt.Errorf("convert: invalid Snowflake ID %d", id)
+	}
+	epochMs := epoch.UnixMilli()
+	if epochMs < 0 || epochMs >= 1<<48 {
+		return uuid.Nil, fmt.Errorf("convert: Snowflake epoch %s out of range of UUID timestamp", epoch)
+	}
+	ms := id>>(snowflakeWorkerBits+snowflakeSeqBits) + epochMs
+	if ms >= 1<<48 {
+		return uuid.Nil, fmt.Errorf("convert: Snowflake ID %d time out of range of UUID timestamp", id)
+	}
+	worker := uint16(id>>snowflakeSeqBits) & (1<<snowflakeWorkerBits - 1)
+	seq := uint16(id) & (1<<snowflakeSeqBits - 1)
+
+	u := uuid.UUID{}
+	putUint48(u[0:6], uint64(ms))
+	binary.BigEndian.PutUint16(u[6:8], seq)
+	binary.BigEndian.PutUint16(u[8:10], worker)
+
+	return finalize(u), nil
+}
+
+// ToSnowflake returns Snowflake ID counting time since epoch converted
+// back from UUID returned by FromSnowflake.
+func ToSnowflake(u uuid.UUID, epoch time.Time) (int64, error) {
+	if err := checkV7(u); err != nil {
+		return 0, err
+	}
+	if u[8]&0x3c != 0 || !isZero(u[10:]) {
+		return 0, fmt.Errorf("convert: UUID %s wasn't converted from Snowflake ID", u)
+	}
+
+	ms := int64(uint48(u[0:6])) - epoch.UnixMilli()
+	if ms < 0 || ms >= 1<<snowflakeTimeBits {
+		return 0, fmt.Errorf("convert: UUID %s time is out of Snowflake ID range", u)
+	}
+	worker := int64(binary.BigEndian.Uint16(u[8:10]) & (1<<snowflakeWorkerBits - 1))
+	seq := int64(binary.BigEndian.Uint16(u[6:8]) & (1<<snowflakeSeqBits - 1))
+
+	return ms<<(snowflakeWorkerBits+snowflakeSeqBits) | worker<<snowflakeSeqBits | seq, nil
+}
+
+func checkV7(u uuid.UUID) error {
+	if u.Version() != uuid.V7 || u.Variant() != uuid.VariantRFC4122 {
+		return fmt.Errorf("convert: expected version %d UUID, got %s", uuid.V7, u)
+	}
+	return nil
+}
+
+func finalize(u uuid.UUID) uuid.UUID {
+	u.SetVersion(uuid.V7)
+	u.SetVariant(uuid.VariantRFC4122)
+	return u
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func putUint48(b []byte, v uint64) {
+	b[0] = byte(v >> 40)
+	b[1] = byte(v >> 32)
+	b[2] = byte(v >> 24)
+	b[3] = byte(v >> 16)
+	b[4] = byte(v >> 8)
+	b[5] = byte(v)
+}
+
+func uint48(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package convert
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestFromKSUID(t *testing.T) {
+	// 0ujtsYcgvSTl8PAuAdqWYSMnLOv
+	id := mustDecodeHex("0669f7efb5a1cd34b5f99d1154fb6853345c9735")
+
+	u, err := FromKSUID(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.V7, u.Version())
+	assert.Equal(t, uuid.VariantRFC4122, u.Variant())
+
+	ts, err := uuid.TimestampFromV7(u)
+	require.NoError(t, err)
+	assert.Equal(t, int64(107608047+ksuidEpoch), ts.Unix())
+
+	_, err = FromKSUID(id[:10])
+	assert.Error(t, err)
+}
+
+func TestXID(t *testing.T) {
+	// 9m4e2mr0ui3e8a215n4g
+	id := mustDecodeHex("4d88e15b60f486e428412dc9")
+
+	u, err := FromXID(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.V7, u.Version())
+	assert.Equal(t, uuid.VariantRFC4122, u.Variant())
+
+	ts, err := uuid.TimestampFromV7(u)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0x4d88e15b), ts.Unix())
+
+	id1, err := ToXID(u)
+	require.NoError(t, err)
+	assert.Equal(t, id, id1)
+
+	_, err = FromXID(id[:10])
+	assert.Error(t, err)
+
+	_, err = ToXID(uuid.MustNewV4())
+	assert.Error(t, err)
+
+	_, err = ToXID(uuid.MustNewV7())
+	assert.Error(t, err)
+}
+
+func TestSnowflake(t *testing.T) {
+	// Twitter Snowflake epoch.
+	epoch := time.UnixMilli(1288834974657)
+	id := int64(1541815603606036480)
+
+	u, err := FromSnowflake(id, epoch)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.V7, u.Version())
+	assert.Equal(t, uuid.VariantRFC4122, u.Variant())
+
+	ts, err := uuid.TimestampFromV7(u)
+	require.NoError(t, err)
+	assert.Equal(t, epoch.Add(time.Duration(id>>22)*time.Millisecond).UnixMilli(), ts.UnixMilli())
+
+	id1, err := ToSnowflake(u, epoch)
+	require.NoError(t, err)
+	assert.Equal(t, id, id1)
+
+	_, err = FromSnowflake(-1, epoch)
+	assert.Error(t, err)
+
+	_, err = FromSnowflake(id, time.UnixMilli(-1))
+	assert.Error(t, err)
+
+	_, err = FromSnowflake(id, time.UnixMilli(1<<48-1))
+	assert.Error(t, err)
+
+	_, err = ToSnowflake(u, time.Now().Add(time.Hour))
+	assert.Error(t, err)
+
+	_, err = ToSnowflake(uuid.MustNewV7(), epoch)
+	assert.Error(t, err)
+}
+
+func TestSnowflakeOrder(t *testing.T) {
+	epoch := time.UnixMilli(1288834974657)
+	u1, err := FromSnowflake(1541815603606036480, epoch)
+	require.NoError(t, err)
+	u2, err := FromSnowflake(1541815603606036481, epoch)
+	require.NoError(t, err)
+	assert.Less(t, u1.String(), u2.String())
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor if token is malformed
+// or isn't signed with the given key.
+var ErrInvalidCursor = errors.New("uuid: invalid cursor")
+
+// Cursor format version and flags of optional fields.
+const (
+	cursorVersion   = 1
+	cursorHasOffset = 1 << 0
+	cursorHasTime   = 1 << 1
+)
+
+// Size of truncated HMAC-SHA256 signature of cursor.
+const cursorSigSize = 16
+
+// Cursor is a position in keyset pagination over UUID keys, usually
+// the key of the last row of a page. Offset and Time are optional
+// secondary keys, omitted from the token when zero.
+type Cursor struct {
+	ID     UUID
+	Offset int64
+	Time   time.Time
+}
+
+// EncodeCursor returns an opaque, URL-safe token of cursor c signed with
+// HMAC-SHA256 keyed with key. The token isn't encrypted: its content
+// can be read, but not forged without the key.
+func EncodeCursor(c Cursor, key []byte) string {
+	buf := make([]byte, 2, 2+Size+binary.MaxVarintLen64+12+cursorSigSize)
+	buf[0] = cursorVersion
+	buf = append(buf, c.ID[:]...)
+	if c.Offset != 0 {
+		buf[1] |= cursorHasOffset
+		buf = binary.AppendVarint(buf, c.Offset)
+	}
+	if !c.Time.IsZero() {
+		buf[1] |= cursorHasTime
+		buf = binary.BigEndian.AppendUint64(buf, uint64(c.Time.Unix()))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(c.Time.Nanosecond()))
+	}
+	buf = append(buf, signCursor(buf, key)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeCursor returns cursor decoded from token returned by EncodeCursor
+// with the same key. Time is returned in UTC.
+func DecodeCursor(token string, key []byte) (Cursor, error) {
+	var c Cursor
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(buf) < 2+Size+cursorSigSize {
+		return c, ErrInvalidCursor
+	}
+
+	data, sig := buf[:len(buf)-cursorSigSize], buf[len(buf)-cursorSigSize:]
+	if !hmac.Equal(sig, signCursor(data, key)) {
+		return c, ErrInvalidCursor
+	}
+	if data[0] != cursorVersion || data[1]&^(cursorHasOffset|cursorHasTime) != 0 {
+		return c, ErrInvalidCursor
+	}
+
+	flags := data[1]
+	data = data[2:]
+	copy(c.ID[:], data)
+	data = data[Size:]
+	if flags&cursorHasOffset != 0 {
+		offset, n := binary.Varint(data)
+		if n <= 0 {
+			return Cursor{}, ErrInvalidCursor
+		}
+		c.Offset = offset
+		data = data[n:]
+	}
+	if flags&cursorHasTime != 0 {
+		if len(data) < 12 {
+			return Cursor{}, ErrInvalidCursor
+		}
+		sec := int64(binary.BigEndian.Uint64(data))
+		nsec := binary.BigEndian.Uint32(data[8:])
+		if nsec >= uint32(time.Second) {
+			return Cursor{}, ErrInvalidCursor
+		}
+		c.Time = time.Unix(sec, int64(nsec)).UTC()
+		data = data[12:]
+	}
+	if len(data) != 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Returns truncated HMAC-SHA256 signature of cursor data.
+func signCursor(data, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)[:cursorSigSize]
+}
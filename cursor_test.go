@@ -0,0 +1,96 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestCursor(t *testing.T) {
+	key := []byte("secret")
+	ts := time.Date(2023, 6, 1, 12, 30, 0, 123456789, time.UTC)
+	tests := []Cursor{
+		{ID: NamespaceDNS},
+		{ID: NamespaceDNS, Offset: 42},
+		{ID: NamespaceDNS, Offset: -7},
+		{ID: NamespaceDNS, Time: ts},
+		{ID: NamespaceURL, Offset: 1 << 40, Time: ts},
+		// Outside of range of int64 nanoseconds since Unix epoch.
+		{ID: NamespaceDNS, Time: time.Date(1582, 10, 15, 0, 0, 0, 1, time.UTC)},
+		{ID: NamespaceDNS, Time: time.Date(3000, 1, 1, 0, 0, 0, 999999999, time.UTC)},
+		{},
+	}
+	for _, c := range tests {
+		token := EncodeCursor(c, key)
+		assert.NotContains(t, token, "=")
+		assert.NotContains(t, token, "+")
+		assert.NotContains(t, token, "/")
+
+		c2, err := DecodeCursor(token, key)
+		require.NoError(t, err)
+		assert.Equal(t, c.ID, c2.ID)
+		assert.Equal(t, c.Offset, c2.Offset)
+		assert.True(t, c.Time.Equal(c2.Time), "%v != %v", c.Time, c2.Time)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	key := []byte("secret")
+	token := EncodeCursor(Cursor{ID: NamespaceDNS, Offset: 42}, key)
+
+	_, err := DecodeCursor(token, []byte("other"))
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	buf[2] ^= 0x01
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString(buf), key)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	// Correctly signed, but malformed data.
+	data := append([]byte{cursorVersion, cursorHasTime}, NamespaceDNS[:]...)
+	data = append(data, signCursor(data, key)...)
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString(data), key)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	// Nanoseconds out of range.
+	data = append([]byte{cursorVersion, cursorHasTime}, NamespaceDNS[:]...)
+	data = append(data, 0, 0, 0, 0, 0, 0, 0, 0, 0x3b, 0x9a, 0xca, 0x00)
+	data = append(data, signCursor(data, key)...)
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString(data), key)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	data = append([]byte{2, 0}, NamespaceDNS[:]...)
+	data = append(data, signCursor(data, key)...)
+	_, err = DecodeCursor(base64.RawURLEncoding.EncodeToString(data), key)
+	assert.Equal(t, ErrInvalidCursor, err)
+
+	for _, token := range []string{"", "!!!", "AQA"} {
+		_, err = DecodeCursor(token, key)
+		assert.Equal(t, ErrInvalidCursor, err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Domain is a DCE domain of version 2 UUID, telling whether the local
+// identifier embedded into it is a user, a group or an organization.
+type Domain byte
+
+// ErrUnknownDomain is returned when generating DCE Security UUID
+// for a domain other than DomainPerson, DomainGroup or DomainOrg.
+var ErrUnknownDomain = errors.New("uuid: unknown DCE domain")
+
+// Valid returns true if d is one of DomainPerson, DomainGroup
+// and DomainOrg.
+func (d Domain) Valid() bool {
+	return d <= DomainOrg
+}
+
+// String returns name of domain as used by DCE, like "person".
+func (d Domain) String() string {
+	switch d {
+	case DomainPerson:
+		return "person"
+	case DomainGroup:
+		return "group"
+	case DomainOrg:
+		return "org"
+	default:
+		return fmt.Sprintf("Domain(%d)", byte(d))
+	}
+}
+
+// ErrUnsupportedPlatform is returned when generating DCE Security UUID
+// on a platform without POSIX UID/GID, such as Windows, and no
+// IDProvider is configured with WithIDProvider.
+var ErrUnsupportedPlatform = errors.New("uuid: POSIX UID/GID are not available on this platform")
+
+// IDProvider supplies local user and group identifiers embedded
+// into DCE Security UUIDs. Implement it to derive stable identifiers
+// on platforms without POSIX UID/GID, e.g. from a Windows SID.
+type IDProvider interface {
+	UID() (uint32, error)
+	GID() (uint32, error)
+}
+
+// WithIDProvider makes generator take local identifiers of
+// DCE Security UUIDs from p instead of POSIX UID/GID.
+func WithIDProvider(p IDProvider) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.idProvider = p
+	}
+}
+
+// posixIDProvider provides POSIX UID/GID of the current process.
+type posixIDProvider struct{}
+
+func (posixIDProvider) UID() (uint32, error) {
+	return posixID(posixUID)
+}
+
+func (posixIDProvider) GID() (uint32, error) {
+	return posixID(posixGID)
+}
+
+// posixID converts id returned by os.Getuid or os.Getgid,
+// which is -1 on platforms without POSIX UID/GID.
+func posixID(id int) (uint32, error) {
+	if id < 0 {
+		return 0, ErrUnsupportedPlatform
+	}
+	return uint32(id), nil
+}
+
+// Returns IDProvider configured for generator or
+// the POSIX one by default.
+func (g *rfc4122Generator) getIDProvider() IDProvider {
+	if g.idProvider == nil {
+		return posixIDProvider{}
+	}
+	return g.idProvider
+}
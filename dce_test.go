@@ -0,0 +1,100 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+type fixedIDProvider struct {
+	uid, gid uint32
+	err      error
+}
+
+func (p fixedIDProvider) UID() (uint32, error) { return p.uid, p.err }
+func (p fixedIDProvider) GID() (uint32, error) { return p.gid, p.err }
+
+func TestWithIDProvider(t *testing.T) {
+	g := NewGenerator(WithIDProvider(fixedIDProvider{uid: 1001, gid: 2002}))
+
+	u1, err := g.NewV2(DomainPerson)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1001), binary.BigEndian.Uint32(u1[:]))
+	assert.Equal(t, byte(DomainPerson), u1[9])
+	assert.Equal(t, V2, u1.Version())
+
+	u2, err := g.NewV2(DomainGroup)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2002), binary.BigEndian.Uint32(u2[:]))
+	assert.Equal(t, byte(DomainGroup), u2[9])
+}
+
+func TestWithIDProviderError(t *testing.T) {
+	g := NewGenerator(WithIDProvider(fixedIDProvider{err: ErrUnsupportedPlatform}))
+
+	u, err := g.NewV2(DomainPerson)
+	assert.True(t, errors.Is(err, ErrUnsupportedPlatform))
+	assert.Equal(t, Nil, u)
+
+	_, err = g.NewV2(DomainGroup)
+	assert.True(t, errors.Is(err, ErrUnsupportedPlatform))
+
+	// Organization domain carries no local identifier.
+	u, err = g.NewV2(DomainOrg)
+	require.NoError(t, err)
+	assert.Equal(t, V2, u.Version())
+}
+
+func TestUnknownDomain(t *testing.T) {
+	g := NewGenerator(WithIDProvider(fixedIDProvider{uid: 1001, gid: 2002}))
+	u, err := g.NewV2(Domain(3))
+	assert.True(t, errors.Is(err, ErrUnknownDomain))
+	assert.EqualError(t, err, "uuid: unknown DCE domain 3")
+	assert.Equal(t, Nil, u)
+
+	_, err = NewV2(Domain(255))
+	assert.True(t, errors.Is(err, ErrUnknownDomain))
+}
+
+func TestDomainString(t *testing.T) {
+	assert.Equal(t, "person", DomainPerson.String())
+	assert.Equal(t, "group", DomainGroup.String())
+	assert.Equal(t, "org", DomainOrg.String())
+	assert.Equal(t, "Domain(42)", Domain(42).String())
+
+	assert.True(t, DomainOrg.Valid())
+	assert.False(t, Domain(3).Valid())
+}
+
+func TestPosixID(t *testing.T) {
+	id, err := posixID(1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1000), id)
+
+	_, err = posixID(-1)
+	assert.Equal(t, ErrUnsupportedPlatform, err)
+}
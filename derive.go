@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// Domain separation of hash used by DeriveOrdered.
+const deriveOrderedDomain = "uuid.DeriveOrdered"
+
+// DeriveOrdered returns version 8 UUID derived from u, typically a random
+// version 4 primary key, and t, typically the creation time of the row
+// it identifies. It's ordered by time like version 7 UUIDs, which makes
+// it a better clustered index key. Layout of the UUID is as follows:
+//
+//	bytes 0 to 5     Unix timestamp of t in milliseconds
+//	bytes 6 to 15    SHA-256 of u, except for version and variant bits
+//
+// The result depends only on u and t, so migrations can compute it
+// repeatedly and in parallel. A migration of a table keyed by version 4
+// UUIDs, keeping references working, may go as follows:
+//
+//  1. Add a column filled with DeriveOrdered(id, created_at) and
+//     a unique index on it.
+//  2. Fill matching columns of referencing tables by joining on the old
+//     key, then switch foreign keys to the new columns.
+//  3. Keep the old key, or a table mapping old keys to new ones, for
+//     lookups by identifiers already handed out. MatchesOrdered checks
+//     whether an old key maps to a given new one.
+//
+// The original UUID can't be recovered from the derived one. It will
+// return error if t is out of range of 48-bit timestamp, i.e. before
+// 1970-01-01 or after 10889-08-02.
+func DeriveOrdered(u UUID, t time.Time) (UUID, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return Nil, fmt.Errorf("uuid: time %s out of range of DeriveOrdered", t)
+	}
+	sum := sha256.Sum256(append([]byte(deriveOrderedDomain), u[:]...))
+	res := UUID{}
+	putUint48(res[:6], uint64(ms))
+	copy(res[6:], sum[:])
+	return finalizeUUID(res, V8), nil
+}
+
+// MatchesOrdered returns true if derived was returned by DeriveOrdered
+// for original, with any timestamp.
+func MatchesOrdered(derived, original UUID) bool {
+	t, err := DerivedOrderedTime(derived)
+	if err != nil {
+		return false
+	}
+	d, err := DeriveOrdered(original, t)
+	return err == nil && d == derived
+}
+
+// DerivedOrderedTime returns timestamp stored in UUID returned by
+// DeriveOrdered, truncated to milliseconds.
+// It will return error if UUID isn't of version 8.
+func DerivedOrderedTime(derived UUID) (time.Time, error) {
+	if derived.Version() != V8 {
+		return time.Time{}, fmt.Errorf("uuid: expected version %d, got version %d", V8, derived.Version())
+	}
+	return time.UnixMilli(int64(getUint48(derived[:6]))), nil
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestDeriveOrdered(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	u := Must(NewV4())
+
+	d := Must(DeriveOrdered(u, created))
+	assert.Equal(t, V8, d.Version())
+	assert.Equal(t, VariantRFC4122, d.Variant())
+	assert.Equal(t, d, Must(DeriveOrdered(u, created)))
+	assert.NotEqual(t, d, Must(DeriveOrdered(Must(NewV4()), created)))
+
+	ts, err := DerivedOrderedTime(d)
+	require.NoError(t, err)
+	assert.True(t, created.Truncate(time.Millisecond).Equal(ts))
+
+	_, err = DerivedOrderedTime(u)
+	assert.Error(t, err)
+}
+
+func TestDeriveOrderedOutOfRange(t *testing.T) {
+	u := Must(NewV4())
+	for _, ts := range []time.Time{
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.UnixMilli(1 << 48),
+	} {
+		_, err := DeriveOrdered(u, ts)
+		assert.Error(t, err, ts)
+	}
+
+	d, err := DeriveOrdered(u, time.UnixMilli(1<<48-1))
+	require.NoError(t, err)
+	ts, err := DerivedOrderedTime(d)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1<<48-1), ts.UnixMilli())
+}
+
+func TestDeriveOrderedOrder(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev := Nil
+	for i := 0; i < 100; i++ {
+		d := Must(DeriveOrdered(Must(NewV4()), created.Add(time.Duration(i)*time.Millisecond)))
+		assert.Equal(t, -1, bytes.Compare(prev[:], d[:]))
+		prev = d
+	}
+}
+
+func TestMatchesOrdered(t *testing.T) {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	u := Must(NewV4())
+	d := Must(DeriveOrdered(u, created))
+
+	assert.True(t, MatchesOrdered(d, u))
+	assert.False(t, MatchesOrdered(d, Must(NewV4())))
+	assert.False(t, MatchesOrdered(u, u))
+}
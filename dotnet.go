@@ -0,0 +1,142 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatDotNet returns string representation of UUID in a format of
+// .NET Guid.ToString given by specifier, in either case:
+//
+//	N: 6ba7b8109dad11d180b400c04fd430c8
+//	D: 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	B: {6ba7b810-9dad-11d1-80b4-00c04fd430c8}
+//	P: (6ba7b810-9dad-11d1-80b4-00c04fd430c8)
+//	X: {0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}
+//
+// Text representation is the same in both ecosystems, only the binary
+// one differs in byte order of the first three fields.
+func FormatDotNet(u UUID, spec byte) (string, error) {
+	switch spec {
+	case 'N', 'n':
+		return u.Formatted(FormatHashLike), nil
+	case 'D', 'd':
+		return u.String(), nil
+	case 'B', 'b':
+		return u.Formatted(FormatBraced), nil
+	case 'P', 'p':
+		return "(" + u.String() + ")", nil
+	case 'X', 'x':
+		var b strings.Builder
+		b.Grow(68)
+		fmt.Fprintf(&b, "{0x%08x,0x%04x,0x%04x,{", binary.BigEndian.Uint32(u[0:]),
+			binary.BigEndian.Uint16(u[4:]), binary.BigEndian.Uint16(u[6:]))
+		for i, c := range u[8:] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, "0x%02x", c)
+		}
+		b.WriteString("}}")
+		return b.String(), nil
+	default:
+		return "", fmt.Errorf("uuid: unknown .NET format specifier %q", spec)
+	}
+}
+
+// ParseDotNet returns UUID parsed from string input in any format
+// returned by FormatDotNet. Hex digits are accepted in any case and
+// numbers of X format may omit leading zeros, as .NET Guid.Parse allows.
+func ParseDotNet(input string) (UUID, error) {
+	switch {
+	case len(input) == 38 && input[0] == '(' && input[37] == ')':
+		return FromString(input[1:37])
+	case strings.HasPrefix(input, "{0x") || strings.HasPrefix(input, "{0X"):
+		return parseDotNetHex(input)
+	default:
+		// N, D and B formats are supported by UnmarshalText.
+		return FromString(input)
+	}
+}
+
+// Parses UUID in .NET X format.
+func parseDotNetHex(input string) (UUID, error) {
+	u := UUID{}
+	fail := func() (UUID, error) {
+		return Nil, fmt.Errorf("uuid: incorrect .NET X format: %s", errorInput(input))
+	}
+
+	s, ok := strings.CutPrefix(input, "{")
+	if !ok {
+		return fail()
+	}
+	s, ok = strings.CutSuffix(s, "}}")
+	if !ok {
+		return fail()
+	}
+	fields, bytes, ok := strings.Cut(s, ",{")
+	if !ok {
+		return fail()
+	}
+
+	parts := strings.Split(fields, ",")
+	if len(parts) != 3 {
+		return fail()
+	}
+	for i, bits := range []int{32, 16, 16} {
+		v, ok := parseDotNetNumber(parts[i], bits)
+		if !ok {
+			return fail()
+		}
+		if i == 0 {
+			binary.BigEndian.PutUint32(u[0:], uint32(v))
+		} else {
+			binary.BigEndian.PutUint16(u[2+2*i:], uint16(v))
+		}
+	}
+
+	parts = strings.Split(bytes, ",")
+	if len(parts) != 8 {
+		return fail()
+	}
+	for i, part := range parts {
+		v, ok := parseDotNetNumber(part, 8)
+		if !ok {
+			return fail()
+		}
+		u[8+i] = byte(v)
+	}
+	return u, nil
+}
+
+// Parses "0x" prefixed hex number of at most given bits.
+func parseDotNetNumber(s string, bits int) (uint64, bool) {
+	if len(s) < 3 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') || len(s)-2 > bits/4 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s[2:], 16, bits)
+	return v, err == nil
+}
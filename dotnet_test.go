@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestFormatDotNet(t *testing.T) {
+	tests := []struct {
+		spec     byte
+		expected string
+	}{
+		{'N', "6ba7b8109dad11d180b400c04fd430c8"},
+		{'D', "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{'B', "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{'P', "(6ba7b810-9dad-11d1-80b4-00c04fd430c8)"},
+		{'X', "{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}"},
+		{'x', "{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}"},
+		{'n', "6ba7b8109dad11d180b400c04fd430c8"},
+	}
+	for _, tt := range tests {
+		s, err := FormatDotNet(NamespaceDNS, tt.spec)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, s)
+
+		u, err := ParseDotNet(s)
+		require.NoError(t, err)
+		assert.Equal(t, NamespaceDNS, u)
+	}
+
+	_, err := FormatDotNet(NamespaceDNS, 'Z')
+	assert.Error(t, err)
+}
+
+func TestParseDotNet(t *testing.T) {
+	tests := []string{
+		"(6BA7B810-9DAD-11D1-80B4-00C04FD430C8)",
+		"{0X6BA7B810,0X9DAD,0X11D1,{0X80,0XB4,0X00,0XC0,0X4F,0XD4,0X30,0XC8}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x0,0xc0,0x4f,0xd4,0x30,0xc8}}",
+	}
+	for _, input := range tests {
+		u, err := ParseDotNet(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, NamespaceDNS, u)
+	}
+
+	u, err := ParseDotNet("{0x1,0x2,0x3,{0x4,0x5,0x6,0x7,0x8,0x9,0xa,0xb}}")
+	require.NoError(t, err)
+	assert.Equal(t, "00000001-0002-0003-0405-060708090a0b", u.String())
+}
+
+func TestParseDotNetInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8]",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430cz)",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}",
+		"{0x6ba7b810,0x9dad,0x11d1,0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}",
+		"{0x6ba7b810,0x9dad,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30}}",
+		"{0x16ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xc8}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0x1c8}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0xzz}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,c8}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0x}}",
+		"{0x6ba7b810,0x9dad,0x11d1,{0x80,0xb4,0x00,0xc0,0x4f,0xd4,0x30,0x+8}}",
+	}
+	for _, input := range tests {
+		_, err := ParseDotNet(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Size of sample read from entropy source during health check.
+const entropySampleSize = 16
+
+// EntropyError is returned by generator configured with
+// WithEntropySources when none of entropy sources produces random bytes.
+type EntropyError struct {
+	// Errs holds the last failure of each source, in chain order.
+	Errs []error
+}
+
+// Error implements the error interface.
+func (e *EntropyError) Error() string {
+	return fmt.Sprintf("uuid: all %d entropy sources failed: %v", len(e.Errs), errors.Join(e.Errs...))
+}
+
+// Unwrap returns failures of entropy sources.
+func (e *EntropyError) Unwrap() []error {
+	return e.Errs
+}
+
+// WithEntropySources makes generator read random bits from an ordered
+// chain of sources, e.g. crypto/rand.Reader followed by a user-supplied
+// CSPRNG. There's no separate getrandom stage, as crypto/rand.Reader
+// already reads from getrandom(2) where available.
+//
+// Each source is health-checked when the generator is created by reading
+// a sample, which must succeed and must not be all zeros or all ones.
+// Random bits are read from the first healthy source; a source failing
+// the check or a later read is skipped in favor of the next one for the
+// life of the generator, with no retry, even if the failure was transient.
+// EntropyError is returned once all sources have failed.
+func WithEntropySources(sources ...io.Reader) GeneratorOption {
+	sources = append([]io.Reader(nil), sources...)
+	return func(g *rfc4122Generator) {
+		// Each generator tracks health of the sources on its own.
+		chain := &entropyChain{
+			sources: sources,
+			errs:    make([]error, len(sources)),
+		}
+		chain.healthCheck()
+		g.rand = chain
+	}
+}
+
+// entropyChain reads from the first healthy of its sources.
+type entropyChain struct {
+	sources []io.Reader
+
+	mu      sync.Mutex
+	errs    []error
+	current int
+}
+
+func (c *entropyChain) Read(p []byte) (int, error) {
+	for {
+		c.mu.Lock()
+		i := c.current
+		c.mu.Unlock()
+
+		if i == len(c.sources) {
+			return 0, c.err()
+		}
+		n, err := io.ReadFull(c.sources[i], p)
+		if err == nil {
+			return n, nil
+		}
+		c.fail(i, err)
+	}
+}
+
+// Checks all sources and skips the unhealthy ones.
+func (c *entropyChain) healthCheck() {
+	sample := make([]byte, entropySampleSize)
+	for i, src := range c.sources {
+		if _, err := io.ReadFull(src, sample); err != nil {
+			c.fail(i, err)
+		} else if isDegenerate(sample) {
+			c.fail(i, ErrDegenerateRandom)
+		}
+	}
+}
+
+// Records failure of source i and moves the chain past it.
+func (c *entropyChain) fail(i int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errs[i] = fmt.Errorf("entropy source %d: %w", i, err)
+	for c.current < len(c.sources) && c.errs[c.current] != nil {
+		c.current++
+	}
+}
+
+// Returns error reporting failures of all sources.
+func (c *entropyChain) err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &EntropyError{Errs: append([]error(nil), c.errs...)}
+}
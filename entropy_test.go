@@ -0,0 +1,107 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestWithEntropySources(t *testing.T) {
+	g := NewGenerator(WithEntropySources(rand.Reader))
+	u, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, V4, u.Version())
+}
+
+func TestWithEntropySourcesHealthCheck(t *testing.T) {
+	// First source is degenerate, second fails, third is healthy.
+	g := NewGenerator(WithEntropySources(constReader(0x00), &faultyReader{}, constReader(0x5a)))
+	u, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x5a), u[0])
+}
+
+func TestWithEntropySourcesCheckOnCreate(t *testing.T) {
+	r := &faultyReader{}
+	g := NewGenerator(WithEntropySources(r, constReader(0x5a))).(*rfc4122Generator)
+	assert.Equal(t, 1, g.rand.(*entropyChain).current)
+}
+
+func TestWithEntropySourcesFallback(t *testing.T) {
+	// First source passes the check, but fails the next read.
+	g := NewGenerator(WithEntropySources(&faultyReader{readToFail: 1}, constReader(0x5a)))
+	u, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x5a), u[0])
+
+	u, err = g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, byte(0x5a), u[0])
+}
+
+func TestWithEntropySourcesAllFail(t *testing.T) {
+	g := NewGenerator(WithEntropySources(constReader(0xff), &faultyReader{}))
+	u, err := g.NewV4()
+	assert.Equal(t, Nil, u)
+
+	var entropyErr *EntropyError
+	require.True(t, errors.As(err, &entropyErr))
+	assert.Len(t, entropyErr.Errs, 2)
+	assert.True(t, errors.Is(err, ErrDegenerateRandom))
+	assert.Contains(t, err.Error(), "entropy source 1")
+
+	g = NewGenerator(WithEntropySources())
+	_, err = g.NewV4()
+	assert.True(t, errors.As(err, &entropyErr))
+}
+
+func TestWithEntropySourcesPerGenerator(t *testing.T) {
+	// Both generators check the first source, then g1 fails reading it.
+	opt := WithEntropySources(&faultyReader{readToFail: 2}, constReader(0x5a))
+	g1 := NewGenerator(opt).(*rfc4122Generator)
+	g2 := NewGenerator(opt).(*rfc4122Generator)
+	require.True(t, g1.rand != g2.rand)
+
+	// Failure seen by g1 doesn't move g2 off the first source.
+	_, err := g1.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, 1, g1.rand.(*entropyChain).current)
+	assert.Equal(t, 0, g2.rand.(*entropyChain).current)
+}
+
+func TestEntropyChainShortSource(t *testing.T) {
+	chain := &entropyChain{
+		sources: []io.Reader{io.LimitReader(rand.Reader, entropySampleSize), constReader(0x5a)},
+		errs:    make([]error, 2),
+	}
+	chain.healthCheck()
+	b := make([]byte, 4)
+	_, err := chain.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x5a, 0x5a, 0x5a, 0x5a}, b)
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// Whether errors omit inputs which failed to parse, see SetRedactErrors.
+var redactErrors atomic.Bool
+
+// SetRedactErrors makes parsing errors omit the offending input from
+// their messages, when enabled, as it may contain personal data or
+// secrets accidentally passed instead of UUID. It's meant for production
+// logging: ParseError still carries the full input in its Input field,
+// so it remains available through errors.As for debugging.
+func SetRedactErrors(redact bool) {
+	redactErrors.Store(redact)
+}
+
+// Token classes reported in ParseError.Expected.
+const (
+	expectHexDigit      = "hex digit"
+	expectLowerHexDigit = "lowercase hex digit"
+	expectDash          = "'-'"
+	expectOpenBrace     = "'{'"
+	expectCloseBrace    = "'}'"
+	expectURNPrefix     = "\"urn:uuid:\" prefix"
+	expectTextLength    = "32, 34, 36, 38, 41 or 45 characters"
+	expectCanonicalLen  = "36 characters"
+)
+
+// ParseError describes a failure to parse UUID from its text
+// representation. It reports the offending input, the byte offset
+// of the first invalid character and the class of token expected
+// there, so that callers can produce actionable messages.
+type ParseError struct {
+	// Input is the text which failed to parse.
+	Input string
+	// Offset is the byte offset of the first invalid character in
+	// Input, or -1 if Input has incorrect length.
+	Offset int
+	// Expected describes what was expected at Offset, e.g. "hex digit",
+	// or the accepted lengths if Offset is -1.
+	Expected string
+}
+
+// Error implements the error interface.
+// Message omits the input if SetRedactErrors is enabled.
+func (e *ParseError) Error() string {
+	if redactErrors.Load() {
+		return e.Redact()
+	}
+	return e.message(strconv.Quote(e.Input), true)
+}
+
+// Redact returns error message which omits the input and the offending
+// character, keeping only their positions and lengths.
+func (e *ParseError) Redact() string {
+	return e.message(redactedInput(e.Input), false)
+}
+
+// Returns error message with the input replaced by given text.
+func (e *ParseError) message(input string, withChar bool) string {
+	switch {
+	case e.Offset < 0 || e.Offset >= len(e.Input):
+		return fmt.Sprintf("uuid: incorrect UUID length %d in %s, expected %s", len(e.Input), input, e.Expected)
+	case withChar:
+		return fmt.Sprintf("uuid: invalid character %q at position %d in %s, expected %s", e.Input[e.Offset], e.Offset, input, e.Expected)
+	default:
+		return fmt.Sprintf("uuid: invalid character at position %d in %s, expected %s", e.Offset, input, e.Expected)
+	}
+}
+
+// Returns input to be included into error message,
+// which is redacted if SetRedactErrors is enabled.
+func errorInput[T string | []byte](input T) string {
+	if redactErrors.Load() {
+		return redactedInput(input)
+	}
+	return string(input)
+}
+
+// Returns placeholder of redacted input.
+func redactedInput[T string | []byte](input T) string {
+	return fmt.Sprintf("[%d bytes redacted]", len(input))
+}
+
+// newParseError returns ParseError for text with invalid character
+// at offset.
+func newParseError(text []byte, offset int, expected string) error {
+	return &ParseError{Input: string(text), Offset: offset, Expected: expected}
+}
+
+// newLengthError returns ParseError for text with incorrect length.
+func newLengthError(text []byte, expected string) error {
+	return &ParseError{Input: string(text), Offset: -1, Expected: expected}
+}
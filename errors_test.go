@@ -0,0 +1,131 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		input    string
+		offset   int
+		expected string
+	}{
+		{"z6a7b810-9dad-11d1-80b4-00c04fd430c8", 0, expectHexDigit},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430cz", 35, expectHexDigit},
+		{"6ba7b810-9dad_11d1-80b4-00c04fd430c8", 13, expectDash},
+		{"6ba7b8109dad11d180b400c04fd430g8", 30, expectHexDigit},
+		{"[6ba7b810-9dad-11d1-80b4-00c04fd430c8}", 0, expectOpenBrace},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8]", 37, expectCloseBrace},
+		{"{6ba7b810-9dad-11d1-80b4-00c04fd430x8}", 35, expectHexDigit},
+		{"urn:uuie:6ba7b810-9dad-11d1-80b4-00c04fd430c8", 7, expectURNPrefix},
+		{"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd43-c8", 42, expectHexDigit},
+		{"urn:uuid:6ba7b8109dad11d180b400c04fd430c_", 40, expectHexDigit},
+		{"6ba7b810", -1, expectTextLength},
+	}
+	for _, tt := range tests {
+		_, err := FromString(tt.input)
+		var pe *ParseError
+		require.True(t, errors.As(err, &pe), tt.input)
+		assert.Equal(t, tt.input, pe.Input)
+		assert.Equal(t, tt.offset, pe.Offset, tt.input)
+		assert.Equal(t, tt.expected, pe.Expected, tt.input)
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := FromString("z6a7b810-9dad-11d1-80b4-00c04fd430c8")
+	assert.EqualError(t, err, `uuid: invalid character 'z' at position 0 in "z6a7b810-9dad-11d1-80b4-00c04fd430c8", expected hex digit`)
+
+	_, err = FromString("6ba7b810")
+	assert.EqualError(t, err, `uuid: incorrect UUID length 8 in "6ba7b810", expected 32, 34, 36, 38, 41 or 45 characters`)
+}
+
+func TestParseErrorStrict(t *testing.T) {
+	var u UUID
+	err := u.UnmarshalTextStrict([]byte("6ba7b810-9DAD-11d1-80b4-00c04fd430c8"))
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 10, pe.Offset)
+	assert.Equal(t, expectLowerHexDigit, pe.Expected)
+
+	err = u.UnmarshalTextStrict([]byte("6ba7b810_9dad-11d1-80b4-00c04fd430C8"))
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 8, pe.Offset)
+	assert.Equal(t, expectDash, pe.Expected)
+
+	err = u.UnmarshalTextStrict([]byte("6ba7b810"))
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, -1, pe.Offset)
+	assert.Equal(t, expectCanonicalLen, pe.Expected)
+}
+
+func TestParseErrorSlice(t *testing.T) {
+	_, err := ParseSlice([]string{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "6ba7b810-9dad-11d1-80b4-00c04fd430cz"})
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 35, pe.Offset)
+}
+
+func TestParseErrorRedact(t *testing.T) {
+	_, err := FromString("z6a7b810-9dad-11d1-80b4-00c04fd430c8")
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, "uuid: invalid character at position 0 in [36 bytes redacted], expected hex digit", pe.Redact())
+
+	_, err = FromString("secret")
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, "uuid: incorrect UUID length 6 in [6 bytes redacted], expected 32, 34, 36, 38, 41 or 45 characters", pe.Redact())
+}
+
+func TestSetRedactErrors(t *testing.T) {
+	SetRedactErrors(true)
+	defer SetRedactErrors(false)
+
+	_, err := FromString("password=hunter2")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	// Full input is still available for debugging.
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, "password=hunter2", pe.Input)
+
+	_, err = ParseSlice([]string{"password=hunter2"})
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	_, err = FromProquint("password=hunter2")
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	_, err = ParseDotNet("{0xhunter2}}")
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	var a UUIDArray
+	err = a.Scan("hunter2")
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.Contains(t, err.Error(), "[7 bytes redacted]")
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FormatStyle is a text representation of UUID.
+type FormatStyle int
+
+const (
+	// FormatCanonical is "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	// as returned by String.
+	FormatCanonical FormatStyle = iota
+	// FormatBraced is "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}".
+	FormatBraced
+	// FormatURN is "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+	FormatURN
+	// FormatHashLike is "6ba7b8109dad11d180b400c04fd430c8".
+	FormatHashLike
+	// FormatUpper is "6BA7B810-9DAD-11D1-80B4-00C04FD430C8".
+	FormatUpper
+)
+
+// String returns name of format style.
+func (s FormatStyle) String() string {
+	switch s {
+	case FormatCanonical:
+		return "canonical"
+	case FormatBraced:
+		return "braced"
+	case FormatURN:
+		return "urn"
+	case FormatHashLike:
+		return "hashlike"
+	case FormatUpper:
+		return "upper"
+	default:
+		return fmt.Sprintf("FormatStyle(%d)", int(s))
+	}
+}
+
+// Formatted returns text representation of UUID in given style.
+// Unknown style results in canonical representation.
+func (u UUID) Formatted(style FormatStyle) string {
+	var buf [45]byte
+	switch style {
+	case FormatBraced:
+		buf[0] = '{'
+		encodeCanonical(buf[1:], u)
+		buf[37] = '}'
+		return string(buf[:38])
+	case FormatURN:
+		copy(buf[:], urnPrefix)
+		encodeCanonical(buf[9:], u)
+		return string(buf[:45])
+	case FormatHashLike:
+		hex := buf[:32]
+		for i, c := range u {
+			hex[2*i] = hexDigits[c>>4]
+			hex[2*i+1] = hexDigits[c&0x0f]
+		}
+		return string(hex)
+	case FormatUpper:
+		encodeCanonical(buf[:], u)
+		return string(bytes.ToUpper(buf[:36]))
+	default:
+		return u.String()
+	}
+}
+
+// URN returns URN representation of UUID, as defined by RFC 4122,
+// e.g. "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+// Same as Formatted with FormatURN.
+func (u UUID) URN() string {
+	return u.Formatted(FormatURN)
+}
+
+// Braced returns representation of UUID enclosed in braces,
+// e.g. "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}".
+// Same as Formatted with FormatBraced.
+func (u UUID) Braced() string {
+	return u.Formatted(FormatBraced)
+}
+
+// StringCompact returns representation of UUID as 32 hexadecimal
+// digits without dashes, e.g. "6ba7b8109dad11d180b400c04fd430c8",
+// which fits CHAR(32) database columns. Same as Formatted with
+// FormatHashLike.
+func (u UUID) StringCompact() string {
+	return u.Formatted(FormatHashLike)
+}
+
+// FromAnyString returns UUID parsed from string input in any form
+// accepted by UnmarshalText, along with the style it was found in.
+// Canonical form containing uppercase hex digits is reported as
+// FormatUpper.
+func FromAnyString(input string) (UUID, FormatStyle, error) {
+	u, err := FromString(input)
+	if err != nil {
+		return Nil, 0, err
+	}
+
+	switch len(input) {
+	case 32:
+		return u, FormatHashLike, nil
+	case 34, 38:
+		return u, FormatBraced, nil
+	case 41, 45:
+		return u, FormatURN, nil
+	}
+	for _, c := range []byte(input) {
+		if 'A' <= c && c <= 'F' {
+			return u, FormatUpper, nil
+		}
+	}
+	return u, FormatCanonical, nil
+}
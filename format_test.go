@@ -0,0 +1,107 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestFormatted(t *testing.T) {
+	tests := []struct {
+		style    FormatStyle
+		expected string
+	}{
+		{FormatCanonical, "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{FormatBraced, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{FormatURN, "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{FormatHashLike, "6ba7b8109dad11d180b400c04fd430c8"},
+		{FormatUpper, "6BA7B810-9DAD-11D1-80B4-00C04FD430C8"},
+		{FormatStyle(42), "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	}
+	for _, tt := range tests {
+		s := NamespaceDNS.Formatted(tt.style)
+		assert.Equal(t, tt.expected, s, tt.style.String())
+
+		u, style, err := FromAnyString(s)
+		require.NoError(t, err)
+		assert.Equal(t, NamespaceDNS, u)
+		if tt.style <= FormatUpper {
+			assert.Equal(t, tt.style, style)
+		}
+	}
+}
+
+func TestURN(t *testing.T) {
+	assert.Equal(t, "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8", NamespaceDNS.URN())
+	assert.Equal(t, NamespaceDNS, Must(FromString(NamespaceDNS.URN())))
+	assert.Equal(t, float64(1), testing.AllocsPerRun(100, func() {
+		_ = NamespaceDNS.URN()
+	}))
+}
+
+func TestBraced(t *testing.T) {
+	assert.Equal(t, "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", NamespaceDNS.Braced())
+	assert.Equal(t, NamespaceDNS, Must(FromString(NamespaceDNS.Braced())))
+	assert.Equal(t, float64(1), testing.AllocsPerRun(100, func() {
+		_ = NamespaceDNS.Braced()
+	}))
+}
+
+func TestStringCompact(t *testing.T) {
+	assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8", NamespaceDNS.StringCompact())
+	assert.Equal(t, NamespaceDNS, Must(FromString(NamespaceDNS.StringCompact())))
+}
+
+func TestFromAnyString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FormatStyle
+	}{
+		{"6ba7b8109dad11d180b400c04fd430c8", FormatHashLike},
+		{"6BA7B8109DAD11D180B400C04FD430C8", FormatHashLike},
+		{"urn:uuid:6ba7b8109dad11d180b400c04fd430c8", FormatURN},
+		{"{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}", FormatBraced},
+		{"{6ba7b8109dad11d180b400c04fd430c8}", FormatBraced},
+		{"6ba7b810-9DAD-11d1-80b4-00c04fd430c8", FormatUpper},
+	}
+	for _, tt := range tests {
+		u, style, err := FromAnyString(tt.input)
+		require.NoError(t, err)
+		assert.Equal(t, NamespaceDNS, u)
+		assert.Equal(t, tt.expected, style, tt.input)
+	}
+
+	_, _, err := FromAnyString("invalid")
+	assert.Error(t, err)
+}
+
+func TestFormatStyleString(t *testing.T) {
+	assert.Equal(t, "canonical", FormatCanonical.String())
+	assert.Equal(t, "braced", FormatBraced.String())
+	assert.Equal(t, "urn", FormatURN.String())
+	assert.Equal(t, "hashlike", FormatHashLike.String())
+	assert.Equal(t, "upper", FormatUpper.String())
+	assert.Equal(t, "FormatStyle(42)", FormatStyle(42).String())
+}
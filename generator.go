@@ -22,16 +22,21 @@
 package uuid
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"net"
-	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -39,55 +44,203 @@ import (
 // UUID epoch (October 15, 1582) and Unix epoch (January 1, 1970).
 const epochStart = 122192928000000000
 
-type epochFunc func() time.Time
-type hwAddrFunc func() (net.HardwareAddr, error)
+// Number of times random bits are read again when they turn out
+// to be all zeros or all ones in nil-safe mode.
+const maxRandomRerolls = 3
+
+// ErrDegenerateRandom is returned by generator in nil-safe mode when the
+// random source keeps producing all zero or all one bits.
+var ErrDegenerateRandom = errors.New("uuid: random source produced degenerate output")
+
+// ErrClockRegression is returned by generator with ClockRegressionError
+// policy when the clock goes backwards, or with ClockRegressionStall
+// policy when it doesn't catch up in time.
+var ErrClockRegression = errors.New("uuid: clock moved backwards")
+
+// ClockRegressionPolicy defines how generator of time-based (version 1,
+// 2 and 6) UUIDs reacts to the clock going backwards.
+type ClockRegressionPolicy int
+
+// Clock regression policies.
+const (
+	// ClockRegressionIncrement increments the clock sequence,
+	// as recommended by RFC 4122. It's the default policy.
+	ClockRegressionIncrement ClockRegressionPolicy = iota
+	// ClockRegressionError makes generator return ErrClockRegression.
+	ClockRegressionError
+	// ClockRegressionStall makes generator wait until the clock
+	// catches up with the last generated timestamp. It waits while
+	// holding the lock of the clock sequence, blocking other callers
+	// sharing it, so the wait is capped at one second: generator
+	// returns ErrClockRegression if the clock is further behind.
+	ClockRegressionStall
+	// ClockRegressionRandomize sets the clock sequence to a new
+	// random value.
+	ClockRegressionRandomize
+)
 
-var (
-	global = newRFC4122Generator()
+// Maximum time generator with ClockRegressionStall policy waits for
+// the clock to catch up.
+const maxClockStall = time.Second
 
-	posixUID = uint32(os.Getuid())
-	posixGID = uint32(os.Getgid())
-)
+type epochFunc func() time.Time
+type hwAddrFunc func() (net.HardwareAddr, error)
 
 // NewV1 returns UUID based on current timestamp and MAC address.
 func NewV1() (UUID, error) {
-	return global.NewV1()
+	return global().NewV1()
 }
 
 // NewV2 returns DCE Security UUID based on POSIX UID/GID.
-func NewV2(domain byte) (UUID, error) {
-	return global.NewV2(domain)
+// On platforms without POSIX UID/GID, such as Windows, it returns
+// ErrUnsupportedPlatform for DomainPerson and DomainGroup.
+// It returns ErrUnknownDomain for domains other than the three above.
+func NewV2(domain Domain) (UUID, error) {
+	return global().NewV2(domain)
 }
 
 // NewV3 returns UUID based on MD5 hash of namespace UUID and name.
 func NewV3(ns UUID, name string) UUID {
-	return global.NewV3(ns, name)
+	return global().NewV3(ns, name)
 }
 
 // NewV4 returns random generated UUID.
 func NewV4() (UUID, error) {
-	return global.NewV4()
+	return global().NewV4()
 }
 
 // NewV5 returns UUID based on SHA-1 hash of namespace UUID and name.
 func NewV5(ns UUID, name string) UUID {
-	return global.NewV5(ns, name)
+	return global().NewV5(ns, name)
+}
+
+// NewV3Bytes returns UUID based on MD5 hash of namespace UUID and name.
+// Same behavior as NewV3, but accepts name as a byte slice.
+func NewV3Bytes(ns UUID, name []byte) UUID {
+	return finalizeUUID(newFromHashBytes(md5.New(), ns, name), V3)
+}
+
+// NewV5Bytes returns UUID based on SHA-1 hash of namespace UUID and name.
+// Same behavior as NewV5, but accepts name as a byte slice.
+func NewV5Bytes(ns UUID, name []byte) UUID {
+	return finalizeUUID(newFromHashBytes(sha1.New(), ns, name), V5)
+}
+
+// NewV8Hash returns version 8 UUID based on hash of namespace UUID and
+// name computed by hash function h, such as sha256.New, as shown in
+// RFC 9562 Appendix B.2. It's a replacement of NewV3 and NewV5 where MD5
+// and SHA-1 aren't allowed. It panics if h produces less than 16 bytes.
+func NewV8Hash(ns UUID, name string, h func() hash.Hash) UUID {
+	hh := h()
+	if hh.Size() < Size {
+		panic(fmt.Sprintf("uuid: hash size %d is less than %d bytes", hh.Size(), Size))
+	}
+	return finalizeUUID(newFromHash(hh, ns, name), V8)
+}
+
+// Derive returns UUID derived from namespace UUID through a path of names.
+// Each part is hashed as in NewV5 with the UUID derived from the previous
+// parts as namespace, so that Derive(ns, "a", "b") equals
+// NewV5(NewV5(ns, "a"), "b"). It returns ns if no parts are given.
+//
+// Derive builds stable hierarchical identifiers, such as
+// Derive(ns, tenant, project, resource).
+func Derive(ns UUID, parts ...string) UUID {
+	u := ns
+	for _, part := range parts {
+		u = NewV5(u, part)
+	}
+	return u
+}
+
+// NameWriter is an io.Writer computing name-based UUID of all
+// the data written to it, so that a name doesn't need to be held
+// in memory as a whole.
+type NameWriter struct {
+	h       hash.Hash
+	version byte
+}
+
+// NewV3Writer returns NameWriter computing UUID based on
+// MD5 hash of namespace UUID and name.
+func NewV3Writer(ns UUID) *NameWriter {
+	return newNameWriter(md5.New(), ns, V3)
+}
+
+// NewV5Writer returns NameWriter computing UUID based on
+// SHA-1 hash of namespace UUID and name.
+func NewV5Writer(ns UUID) *NameWriter {
+	return newNameWriter(sha1.New(), ns, V5)
+}
+
+func newNameWriter(h hash.Hash, ns UUID, version byte) *NameWriter {
+	h.Write(ns[:])
+	return &NameWriter{h: h, version: version}
+}
+
+// Write implements the io.Writer interface.
+// It never returns an error.
+func (w *NameWriter) Write(p []byte) (int, error) {
+	return w.h.Write(p)
+}
+
+// UUID returns UUID based on the name written so far.
+// It doesn't change the underlying hash state.
+func (w *NameWriter) UUID() UUID {
+	u := UUID{}
+	copy(u[:], w.h.Sum(nil))
+	return finalizeUUID(u, w.version)
 }
 
 // NewV6 returns UUID
 func NewV6() (UUID, error) {
-	return global.NewV6()
+	return global().NewV6()
 }
 
 // NewV7 returns UUID v7
 func NewV7() (UUID, error) {
-	return global.NewV7()
+	return global().NewV7()
+}
+
+// NewV4OrNil returns random generated UUID.
+// Same behavior as NewV4, but returns a Nil UUID on error. Use it only
+// where failure of the random source can't be recovered from anyway,
+// and check the result against Nil where that matters.
+func NewV4OrNil() UUID {
+	u, err := NewV4()
+	if err != nil {
+		return Nil
+	}
+	return u
+}
+
+// NewV7OrNil returns UUID v7.
+// Same behavior as NewV7, but returns a Nil UUID on error.
+// The same considerations as for NewV4OrNil apply.
+func NewV7OrNil() UUID {
+	u, err := NewV7()
+	if err != nil {
+		return Nil
+	}
+	return u
+}
+
+// NewV4Context returns random generated UUID.
+// It gives up waiting for the random source once ctx is done.
+func NewV4Context(ctx context.Context) (UUID, error) {
+	return global().NewV4Context(ctx)
+}
+
+// NewV7Context returns UUID v7.
+// It gives up waiting for the random source once ctx is done.
+func NewV7Context(ctx context.Context) (UUID, error) {
+	return global().NewV7Context(ctx)
 }
 
 // Generator provides interface for generating UUIDs.
 type Generator interface {
 	NewV1() (UUID, error)
-	NewV2(domain byte) (UUID, error)
+	NewV2(domain Domain) (UUID, error)
 	NewV3(ns UUID, name string) UUID
 	NewV4() (UUID, error)
 	NewV5(ns UUID, name string) UUID
@@ -95,6 +248,14 @@ type Generator interface {
 	NewV7() (UUID, error)
 }
 
+// ContextGenerator provides interface for generating random based UUIDs
+// respecting cancellation and deadline of a context.
+// Generators returned by NewGenerator implement it.
+type ContextGenerator interface {
+	NewV4Context(ctx context.Context) (UUID, error)
+	NewV7Context(ctx context.Context) (UUID, error)
+}
+
 // Default generator implementation.
 type rfc4122Generator struct {
 	clockSequenceOnce sync.Once
@@ -102,33 +263,163 @@ type rfc4122Generator struct {
 	storageMutex      sync.Mutex
 
 	rand io.Reader
+	// Serializes reads of rand which may be abandoned on cancellation,
+	// see readFullContext. randAbandoned counts such reads in progress.
+	randMu        sync.Mutex
+	randAbandoned atomic.Int32
+
+	epochFunc      epochFunc
+	sleepFunc      func(time.Duration)
+	hwAddrFunc     hwAddrFunc
+	v7Epoch        time.Time
+	v7Monotonic    bool
+	v7ClockStart   time.Time
+	v7LastTime     atomic.Uint64
+	v7LastReserved uint64
+	v7NodeID       uint16
+	v7NodeBits     int
+	nilSafe        bool
+	idProvider     IDProvider
+	nodeSelect     NodeSelection
+	nodeIface      string
+	clockPolicy    ClockRegressionPolicy
+	clockObserver  func(ClockEvent)
+	hardwareAddr   [6]byte
+
+	// State of time-based UUIDs generation, split into shards
+	// to reduce lock contention.
+	clockShardCount int
+	clockShards     []clockShard
+	clockSeqMask    uint16
+	clockShardPool  sync.Pool
+	clockShardNext  atomic.Uint32
+	clockProcess    bool
+	clockProcessTag uint16
+
+	// Observability state, see State.
+	clockReady        atomic.Bool
+	clockRegressions  atomic.Uint64
+	hardwareAddrReady atomic.Bool
+	counters          [counterStripes]counterStripe
+}
+
+func newRFC4122Generator() *rfc4122Generator {
+	g := &rfc4122Generator{
+		epochFunc: time.Now,
+		sleepFunc: time.Sleep,
+		rand:      rand.Reader,
+	}
+	g.hwAddrFunc = g.interfaceHWAddr
+	return g
+}
+
+// GeneratorOption configures a Generator returned by NewGenerator.
+type GeneratorOption func(*rfc4122Generator)
+
+// NewGenerator returns a new Generator configured with given options.
+func NewGenerator(opts ...GeneratorOption) Generator {
+	g := newRFC4122Generator()
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithRandReader makes generator read random bits from r
+// instead of crypto/rand.
+func WithRandReader(r io.Reader) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.rand = r
+	}
+}
 
-	epochFunc     epochFunc
-	hwAddrFunc    hwAddrFunc
-	lastTime      uint64
-	clockSequence uint16
-	hardwareAddr  [6]byte
+// WithHashedNodeID makes generator derive the node ID of time-based UUIDs
+// from HMAC-SHA256 of the hardware address keyed with secret, truncated
+// to 48 bits and with the multicast bit set. The node ID is stable per
+// machine, but the hardware address can't be recovered from it.
+func WithHashedNodeID(secret []byte) GeneratorOption {
+	key := append([]byte(nil), secret...)
+	return func(g *rfc4122Generator) {
+		hwAddrFunc := g.hwAddrFunc
+		g.hwAddrFunc = func() (net.HardwareAddr, error) {
+			hwAddr, err := hwAddrFunc()
+			if err != nil {
+				return nil, err
+			}
+			return hashHardwareAddr(hwAddr, key), nil
+		}
+	}
+}
+
+// WithNilSafe makes generator check random bits of version 4 UUIDs and
+// read them again if they are all zeros or all ones, which would result
+// in a UUID looking like Nil or Max. Such output is practically only
+// produced by a broken random source, so ErrDegenerateRandom is returned
+// if it persists.
+func WithNilSafe() GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.nilSafe = true
+	}
 }
 
-func newRFC4122Generator() Generator {
-	return &rfc4122Generator{
-		epochFunc:  time.Now,
-		hwAddrFunc: defaultHWAddrFunc,
-		rand:       rand.Reader,
+// WithClockRegressionPolicy sets policy applied by generator of
+// time-based UUIDs when the clock goes backwards.
+func WithClockRegressionPolicy(policy ClockRegressionPolicy) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.clockPolicy = policy
+	}
+}
+
+// WithV7Epoch makes generator store in version 7 UUIDs the number of
+// milliseconds elapsed since epoch instead of since Unix epoch.
+// Use TimestampFromV7WithEpoch to extract timestamp from such UUIDs.
+// Generating them fails while the clock reads time before epoch.
+func WithV7Epoch(epoch time.Time) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.v7Epoch = epoch
+	}
+}
+
+// WithMonotonicV7 makes generator never store in version 7 UUIDs
+// a timestamp older than the one of previously generated UUID,
+// even when the wall clock goes backwards.
+func WithMonotonicV7() GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.v7Monotonic = true
+	}
+}
+
+// WithMonotonicClockV7 makes generator behave as with WithMonotonicV7
+// option and, in addition, compute timestamps of version 7 UUIDs by
+// adding monotonic clock time elapsed since generator creation to the
+// wall clock time read at creation. Timestamps are then immune to wall
+// clock adjustments, but may drift from the wall clock over time.
+func WithMonotonicClockV7() GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.v7Monotonic = true
+		g.v7ClockStart = g.epochFunc()
 	}
 }
 
 // NewV1 returns UUID based on current timestamp and MAC address.
 func (g *rfc4122Generator) NewV1() (UUID, error) {
+	u, err := g.newV1()
+	if err != nil {
+		return Nil, err
+	}
+	g.countGenerated(V1, 1)
+	return u, nil
+}
+
+// Returns UUID based on current timestamp and MAC address.
+func (g *rfc4122Generator) newV1() (UUID, error) {
 	u := UUID{}
 
 	timeNow, clockSeq, err := g.getClockSequence()
 	if err != nil {
 		return Nil, fmt.Errorf("failed to get clock sequence: %w", err)
 	}
-	binary.BigEndian.PutUint32(u[0:], uint32(timeNow))
-	binary.BigEndian.PutUint16(u[4:], uint16(timeNow>>32))
-	binary.BigEndian.PutUint16(u[6:], uint16(timeNow>>48))
+	putTimeV1(&u, timeNow)
 	binary.BigEndian.PutUint16(u[8:], clockSeq)
 
 	hardwareAddr, err := g.getHardwareAddr()
@@ -144,23 +435,38 @@ func (g *rfc4122Generator) NewV1() (UUID, error) {
 }
 
 // NewV2 returns DCE Security UUID based on POSIX UID/GID.
-func (g *rfc4122Generator) NewV2(domain byte) (UUID, error) {
-	u, err := g.NewV1()
-	if err != nil {
-		return Nil, err
+func (g *rfc4122Generator) NewV2(domain Domain) (UUID, error) {
+	if !domain.Valid() {
+		return Nil, fmt.Errorf("%w %d", ErrUnknownDomain, byte(domain))
 	}
 
+	var id uint32
+	var err error
 	switch domain {
 	case DomainPerson:
-		binary.BigEndian.PutUint32(u[:], posixUID)
+		id, err = g.getIDProvider().UID()
 	case DomainGroup:
-		binary.BigEndian.PutUint32(u[:], posixGID)
+		id, err = g.getIDProvider().GID()
+	}
+	if err != nil {
+		return Nil, err
 	}
 
-	u[9] = domain
+	u, err := g.newV1()
+	if err != nil {
+		return Nil, err
+	}
+
+	switch domain {
+	case DomainPerson, DomainGroup:
+		binary.BigEndian.PutUint32(u[:], id)
+	}
+
+	u[9] = byte(domain)
 
 	u.SetVersion(V2)
 	u.SetVariant(VariantRFC4122)
+	g.countGenerated(V2, 1)
 
 	return u, nil
 }
@@ -174,9 +480,10 @@ func (g *rfc4122Generator) NewV3(ns UUID, name string) UUID {
 // NewV4 returns random generated UUID.
 func (g *rfc4122Generator) NewV4() (UUID, error) {
 	u := UUID{}
-	if _, err := io.ReadFull(g.rand, u[:]); err != nil {
+	if err := g.readRandom(u[:], g.readFull); err != nil {
 		return Nil, fmt.Errorf("failed to generate random UUID: %w", err)
 	}
+	g.countGenerated(V4, 1)
 	return finalizeUUID(u, V4), nil
 }
 
@@ -195,11 +502,8 @@ func (g *rfc4122Generator) NewV6() (UUID, error) {
 		return Nil, fmt.Errorf("failed to get clock sequence: %w", err)
 	}
 
-	// Reorder time fields for V6
-	binary.BigEndian.PutUint16(u[0:], uint16(timeNow>>48)) // time_high
-	binary.BigEndian.PutUint32(u[2:], uint32(timeNow>>16)) // time_mid
-	binary.BigEndian.PutUint16(u[6:], uint16(timeNow))     // time_low
-	binary.BigEndian.PutUint16(u[8:], clockSeq)            // clock_seq
+	putTimeV6(&u, timeNow)
+	binary.BigEndian.PutUint16(u[8:], clockSeq) // clock_seq
 
 	hardwareAddr, err := g.getHardwareAddr()
 	if err != nil {
@@ -209,27 +513,155 @@ func (g *rfc4122Generator) NewV6() (UUID, error) {
 
 	u.SetVersion(V6)
 	u.SetVariant(VariantRFC4122)
+	g.countGenerated(V6, 1)
 
 	return u, nil
 }
 
 // NewV7 returns UUID v7
 func (g *rfc4122Generator) NewV7() (UUID, error) {
+	return g.NewV7Context(context.Background())
+}
+
+// NewV4Context returns random generated UUID.
+// It gives up waiting for the random source once ctx is done.
+func (g *rfc4122Generator) NewV4Context(ctx context.Context) (UUID, error) {
 	u := UUID{}
+	read := func(b []byte) error {
+		return g.readFullContext(ctx, b)
+	}
+	if err := g.readRandom(u[:], read); err != nil {
+		return Nil, fmt.Errorf("failed to generate random UUID: %w", err)
+	}
+	g.countGenerated(V4, 1)
+	return finalizeUUID(u, V4), nil
+}
 
-	// Timestamp in milliseconds since Unix epoch
-	timeNow := uint64(time.Now().UnixNano() / 1e6)
+// Reads random bits into b using read. In nil-safe mode bits are read
+// again when they are all zeros or all ones.
+func (g *rfc4122Generator) readRandom(b []byte, read func([]byte) error) error {
+	for attempt := 0; ; attempt++ {
+		if err := read(b); err != nil {
+			return err
+		}
+		if !g.nilSafe || !isDegenerate(b) {
+			return nil
+		}
+		if attempt == maxRandomRerolls {
+			return ErrDegenerateRandom
+		}
+	}
+}
+
+// Reads exactly len(b) random bytes into b. While a read abandoned by
+// readFullContext is in progress, it waits for the read to finish, so
+// that the random source is never read concurrently with it.
+func (g *rfc4122Generator) readFull(b []byte) error {
+	if g.randAbandoned.Load() > 0 {
+		g.randMu.Lock()
+		defer g.randMu.Unlock()
+	}
+	_, err := io.ReadFull(g.rand, b)
+	return err
+}
+
+// Returns true if all bits of b are zeros or all bits are ones.
+func isDegenerate(b []byte) bool {
+	for _, c := range b[1:] {
+		if c != b[0] {
+			return false
+		}
+	}
+	return b[0] == 0x00 || b[0] == 0xff
+}
+
+// NewV7Context returns UUID v7.
+// It gives up waiting for the random source once ctx is done.
+func (g *rfc4122Generator) NewV7Context(ctx context.Context) (UUID, error) {
+	u := UUID{}
+
+	// Timestamp in milliseconds since Unix or configured epoch
+	timeNow, err := g.getV7Time()
+	if err != nil {
+		return Nil, err
+	}
 	putUint48(u[:6], timeNow)
 
 	// Random data
-	if _, err := io.ReadFull(g.rand, u[6:]); err != nil {
+	if err := g.readFullContext(ctx, u[6:]); err != nil {
 		return Nil, fmt.Errorf("failed to generate random data for UUID V7: %w", err)
 	}
+	g.putV7NodeID(&u)
+	g.countGenerated(V7, 1)
 
-	u.SetVersion(V7)
-	u.SetVariant(VariantRFC4122)
+	return finalizeUUID(u, V7), nil
+}
 
-	return u, nil
+// Reads exactly len(b) random bytes into b, giving up once ctx is done.
+// Sources which may block are read by a goroutine holding randMu into
+// a scratch buffer, copied into b only on success. An abandoned read thus
+// never writes b after readFullContext has returned, and the following
+// reads of the random source wait for it rather than overlap it.
+func (g *rfc4122Generator) readFullContext(ctx context.Context, b []byte) error {
+	if ctx.Done() == nil {
+		return g.readFull(b)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !mayBlock(g.rand) {
+		return g.readFull(b)
+	}
+
+	const (
+		reading = iota
+		finished
+		abandoned
+	)
+	var state atomic.Int32
+	scratch := make([]byte, len(b))
+	done := make(chan error, 1)
+	go func() {
+		g.randMu.Lock()
+		defer g.randMu.Unlock()
+		var err error
+		if state.Load() != abandoned {
+			_, err = io.ReadFull(g.rand, scratch)
+		}
+		if !state.CompareAndSwap(reading, finished) {
+			g.randAbandoned.Add(-1)
+		}
+		done <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		g.randAbandoned.Add(1)
+		if state.CompareAndSwap(reading, abandoned) {
+			return ctx.Err()
+		}
+		// The read finished meanwhile.
+		g.randAbandoned.Add(-1)
+		if err := <-done; err != nil {
+			return err
+		}
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+	}
+	copy(b, scratch)
+	return nil
+}
+
+// Returns true if reads of r may block, unless r is crypto/rand or
+// an in-memory source, which return promptly.
+func mayBlock(r io.Reader) bool {
+	switch r.(type) {
+	case *lockedReader, *bytes.Reader:
+		return false
+	}
+	return r != rand.Reader
 }
 
 func putUint48(b []byte, v uint64) {
@@ -244,31 +676,132 @@ func putUint48(b []byte, v uint64) {
 	b[5] = byte(v)
 }
 
+// Returns milliseconds elapsed since Unix or configured epoch,
+// or error if time is before it.
+func (g *rfc4122Generator) getV7Time() (uint64, error) {
+	var now time.Time
+	if g.v7ClockStart.IsZero() {
+		now = g.epochFunc()
+	} else {
+		now = g.v7ClockStart.Add(g.epochFunc().Sub(g.v7ClockStart))
+	}
+
+	var ms int64
+	if g.v7Epoch.IsZero() {
+		ms = now.UnixMilli()
+	} else {
+		ms = now.Sub(g.v7Epoch).Milliseconds()
+	}
+	if ms < 0 {
+		// Negative timestamp would wrap to the far future.
+		return 0, fmt.Errorf("uuid: time %s is before epoch of version 7 UUIDs", now)
+	}
+	timeNow := uint64(ms)
+	if !g.v7Monotonic {
+		return timeNow, nil
+	}
+
+	// Lock-free maximum, as the latch is on the hot path of all
+	// goroutines generating UUIDs.
+	for {
+		last := g.v7LastTime.Load()
+		if timeNow <= last {
+			return last, nil
+		}
+		if g.v7LastTime.CompareAndSwap(last, timeNow) {
+			return timeNow, nil
+		}
+	}
+}
+
 // Returns epoch and clock sequence.
 func (g *rfc4122Generator) getClockSequence() (uint64, uint16, error) {
 	var err error
 	g.clockSequenceOnce.Do(func() {
-		buf := make([]byte, 2)
-		if _, err = io.ReadFull(g.rand, buf); err != nil {
-			err = fmt.Errorf("failed to read random data for clock sequence: %w", err)
-			return
+		if err = g.initClockShards(); err == nil {
+			g.clockReady.Store(true)
 		}
-		g.clockSequence = binary.BigEndian.Uint16(buf)
 	})
 	if err != nil {
 		return 0, 0, err
 	}
 
-	g.storageMutex.Lock()
-	defer g.storageMutex.Unlock()
+	shard := g.getClockShard()
+	defer g.putClockShard(shard)
 
+	// Observer is called with shard unlocked, so it may generate UUIDs.
+	timeNow, clockSeq, event, err := g.advanceClockShard(shard)
+	if event != nil {
+		g.clockObserver(*event)
+	}
+	return timeNow, clockSeq, err
+}
+
+// Returns epoch and clock sequence of shard, and event to report
+// if the clock hasn't advanced and observer is set.
+func (g *rfc4122Generator) advanceClockShard(shard *clockShard) (uint64, uint16, *ClockEvent, error) {
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	var event *ClockEvent
 	timeNow := g.getEpoch()
-	if timeNow <= g.lastTime {
-		g.clockSequence++
+	if timeNow <= shard.lastTime && g.clockObserver != nil {
+		event = &ClockEvent{
+			Previous:         epochTime(shard.lastTime),
+			Current:          epochTime(timeNow),
+			OldClockSequence: shard.clockSequence & 0x3fff,
+		}
 	}
-	g.lastTime = timeNow
 
-	return timeNow, g.clockSequence, nil
+	var err error
+	if timeNow < shard.lastTime {
+		g.clockRegressions.Add(1)
+		if timeNow, err = g.handleClockRegression(shard, timeNow); err != nil {
+			if event != nil {
+				event.NewClockSequence = event.OldClockSequence
+			}
+			return 0, 0, event, err
+		}
+	}
+	if timeNow <= shard.lastTime {
+		shard.clockSequence = g.nextClockSequence(shard.clockSequence)
+	}
+	shard.lastTime = timeNow
+
+	if event != nil {
+		event.NewClockSequence = shard.clockSequence & 0x3fff
+	}
+	return timeNow, shard.clockSequence, event, nil
+}
+
+// Applies clock regression policy when current timestamp is behind the
+// last generated one. Returns timestamp to use. Must be called with
+// shard mutex held.
+func (g *rfc4122Generator) handleClockRegression(shard *clockShard, timeNow uint64) (uint64, error) {
+	switch g.clockPolicy {
+	case ClockRegressionError:
+		return 0, ErrClockRegression
+	case ClockRegressionStall:
+		var stalled time.Duration
+		for timeNow < shard.lastTime {
+			wait := time.Duration(shard.lastTime-timeNow) * 100
+			if stalled+wait > maxClockStall {
+				return 0, ErrClockRegression
+			}
+			g.sleepFunc(wait)
+			stalled += wait
+			timeNow = g.getEpoch()
+		}
+	case ClockRegressionRandomize:
+		clockSeq, err := g.randomClockSequence()
+		if err != nil {
+			return 0, err
+		}
+		shard.clockSequence = shard.clockSequence&^g.clockSeqMask | clockSeq&g.clockSeqMask
+		// Prevent the new clock sequence from being incremented.
+		shard.lastTime = 0
+	}
+	return timeNow, nil
 }
 
 // Returns hardware address.
@@ -279,10 +812,11 @@ func (g *rfc4122Generator) getHardwareAddr() ([]byte, error) {
 		if hwErr == nil {
 			copy(g.hardwareAddr[:], hwAddr)
 		} else {
-			if _, err = io.ReadFull(g.rand, g.hardwareAddr[:]); err == nil {
+			if err = g.readFull(g.hardwareAddr[:]); err == nil {
 				g.hardwareAddr[0] |= 0x01 // Set multicast bit
 			}
 		}
+		g.hardwareAddrReady.Store(err == nil)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get hardware address: %w", err)
@@ -298,26 +832,26 @@ func (g *rfc4122Generator) getEpoch() uint64 {
 
 // Returns UUID based on hashing of namespace UUID and name.
 func newFromHash(h hash.Hash, ns UUID, name string) UUID {
+	return newFromHashBytes(h, ns, []byte(name))
+}
+
+// Returns UUID based on hashing of namespace UUID and name.
+func newFromHashBytes(h hash.Hash, ns UUID, name []byte) UUID {
 	u := UUID{}
 	h.Write(ns[:])
-	h.Write([]byte(name))
+	h.Write(name)
 	copy(u[:], h.Sum(nil))
 
 	return u
 }
 
-// Returns hardware address.
-func defaultHWAddrFunc() (net.HardwareAddr, error) {
-	ifaces, err := net.Interfaces()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get network interfaces: %w", err)
-	}
-	for _, iface := range ifaces {
-		if len(iface.HardwareAddr) >= 6 {
-			return iface.HardwareAddr, nil
-		}
-	}
-	return nil, fmt.Errorf("uuid: no HW address found")
+// Returns node ID derived from HMAC-SHA256 of hardware address.
+func hashHardwareAddr(hwAddr net.HardwareAddr, secret []byte) net.HardwareAddr {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(hwAddr)
+	node := net.HardwareAddr(mac.Sum(nil)[:6])
+	node[0] |= 0x01 // Set multicast bit
+	return node
 }
 
 func finalizeUUID(u UUID, version byte) UUID {
@@ -23,11 +23,20 @@ package uuid
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"github.com/satori/go.uuid/internal/vectors"
+	"hash"
+	"hash/fnv"
+	"io"
 	"net"
+	"sync"
 	"testing"
 	"testing/iotest"
 	"time"
@@ -46,6 +55,32 @@ func (r *faultyReader) Read(dest []byte) (int, error) {
 	return rand.Read(dest)
 }
 
+// blockingReader blocks reads of random data until block is closed,
+// tracking how many of them run concurrently.
+type blockingReader struct {
+	block chan struct{}
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	reads     int
+}
+
+func (r *blockingReader) Read(dest []byte) (int, error) {
+	r.mu.Lock()
+	r.active++
+	r.reads++
+	r.maxActive = max(r.maxActive, r.active)
+	r.mu.Unlock()
+
+	<-r.block
+
+	r.mu.Lock()
+	r.active--
+	r.mu.Unlock()
+	return rand.Read(dest)
+}
+
 func TestNewV1(t *testing.T) {
 	u1, err := NewV1()
 	require.NoError(t, err)
@@ -73,6 +108,75 @@ func TestNewV1EpochStale(t *testing.T) {
 	assert.NotEqual(t, u1, u2)
 }
 
+// Returns generator of time-based UUIDs reading timestamps from times.
+func newClockGenerator(policy ClockRegressionPolicy, times ...time.Time) *rfc4122Generator {
+	g := newRFC4122Generator()
+	g.epochFunc = func() time.Time {
+		t := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+		return t
+	}
+	WithClockRegressionPolicy(policy)(g)
+	return g
+}
+
+func TestNewV1ClockRegressionIncrement(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionIncrement, now, now.Add(-time.Second))
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, (binary.BigEndian.Uint16(u1[8:])+1)&0x3fff, binary.BigEndian.Uint16(u2[8:])&0x3fff)
+}
+
+func TestNewV1ClockRegressionError(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionError, now, now.Add(-time.Second), now.Add(time.Second))
+
+	_, err := g.NewV1()
+	require.NoError(t, err)
+
+	u2, err := g.NewV6()
+	require.ErrorIs(t, err, ErrClockRegression)
+	assert.Equal(t, Nil, u2)
+
+	_, err = g.NewV1()
+	require.NoError(t, err)
+}
+
+func TestNewV1ClockRegressionStall(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionStall, now, now.Add(-time.Millisecond), now.Add(time.Millisecond))
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, -1, CompareTimeUUID(u1, u2))
+	assert.Equal(t, u1[8:10], u2[8:10])
+}
+
+func TestNewV1ClockRegressionRandomize(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionRandomize, now, now.Add(-time.Second))
+	g.hwAddrFunc = func() (net.HardwareAddr, error) {
+		return net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}, nil
+	}
+	g.rand = &sequenceReader{constReader(0x01), constReader(0x02)}
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x81, 0x01}, u1[8:10])
+
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x82, 0x02}, u2[8:10])
+}
+
 func TestNewV1FaultyRand(t *testing.T) {
 	g := &rfc4122Generator{
 		epochFunc:  time.Now,
@@ -112,6 +216,40 @@ func TestNewV1MissingNetInterfacesAndFaultyRand(t *testing.T) {
 	assert.Equal(t, Nil, u1)
 }
 
+func TestNewV1HashedNodeID(t *testing.T) {
+	hwAddr := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	hwAddrFunc := func() (net.HardwareAddr, error) {
+		return hwAddr, nil
+	}
+	newGenerator := func(secret string) *rfc4122Generator {
+		g := newRFC4122Generator()
+		g.hwAddrFunc = hwAddrFunc
+		WithHashedNodeID([]byte(secret))(g)
+		return g
+	}
+
+	u1, err := newGenerator("secret").NewV1()
+	require.NoError(t, err)
+	assert.NotEqual(t, []byte(hwAddr), u1[10:])
+	assert.Equal(t, byte(0x01), u1[10]&0x01)
+
+	u2, err := newGenerator("secret").NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, u1[10:], u2[10:])
+
+	u3, err := newGenerator("other").NewV1()
+	require.NoError(t, err)
+	assert.NotEqual(t, u1[10:], u3[10:])
+}
+
+func TestNewGenerator(t *testing.T) {
+	g := NewGenerator(WithHashedNodeID([]byte("secret")))
+	u, err := g.NewV1()
+	require.NoError(t, err)
+	assert.Equal(t, V1, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+}
+
 func BenchmarkNewV1(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = NewV1()
@@ -119,6 +257,10 @@ func BenchmarkNewV1(b *testing.B) {
 }
 
 func TestNewV2(t *testing.T) {
+	if _, err := posixID(posixUID); err != nil {
+		t.Skip("POSIX UID/GID are not available on this platform")
+	}
+
 	u1, err := NewV2(DomainPerson)
 	require.NoError(t, err)
 	assert.Equal(t, V2, u1.Version())
@@ -211,12 +353,144 @@ func TestNewV4PartialRead(t *testing.T) {
 	assert.False(t, mostlyZeros, "Generated UUID contains mostly zeros")
 }
 
+func TestNewV4Context(t *testing.T) {
+	u1, err := NewV4Context(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, V4, u1.Version())
+	assert.Equal(t, VariantRFC4122, u1.Variant())
+
+	u2, err := NewV4Context(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, u1, u2)
+}
+
+func TestNewV4ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	u1, err := NewV4Context(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Nil, u1)
+}
+
+func TestNewV4ContextBlockingRand(t *testing.T) {
+	r := &blockingReader{block: make(chan struct{})}
+	g := &rfc4122Generator{
+		epochFunc:  time.Now,
+		hwAddrFunc: defaultHWAddrFunc,
+		rand:       r,
+	}
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		u1, err := g.NewV4Context(ctx)
+		cancel()
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, Nil, u1)
+	}
+
+	// Later reads wait for the abandoned one instead of overlapping it,
+	// and the read queued behind it is skipped.
+	close(r.block)
+	u2, err := g.NewV4()
+	require.NoError(t, err)
+	assert.NotEqual(t, Nil, u2)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	assert.Equal(t, 1, r.maxActive)
+	assert.Equal(t, 2, r.reads)
+}
+
+func TestNewV4ContextFaultyRand(t *testing.T) {
+	g := &rfc4122Generator{
+		epochFunc:  time.Now,
+		hwAddrFunc: defaultHWAddrFunc,
+		rand:       &faultyReader{},
+	}
+	u1, err := g.NewV4Context(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, Nil, u1)
+}
+
+type constReader byte
+
+func (r constReader) Read(dest []byte) (int, error) {
+	for i := range dest {
+		dest[i] = byte(r)
+	}
+	return len(dest), nil
+}
+
+type sequenceReader []io.Reader
+
+func (r *sequenceReader) Read(dest []byte) (int, error) {
+	next := (*r)[0]
+	*r = (*r)[1:]
+	return next.Read(dest)
+}
+
+func TestNewV4NilSafe(t *testing.T) {
+	g := newRFC4122Generator()
+	WithNilSafe()(g)
+	g.rand = &sequenceReader{constReader(0x00), constReader(0xff), rand.Reader}
+
+	u1, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, V4, u1.Version())
+	assert.False(t, isDegenerate(u1[:]))
+}
+
+func TestNewV4NilSafeBrokenRand(t *testing.T) {
+	g := newRFC4122Generator()
+	WithNilSafe()(g)
+	g.rand = constReader(0x00)
+
+	u1, err := g.NewV4()
+	require.ErrorIs(t, err, ErrDegenerateRandom)
+	assert.Equal(t, Nil, u1)
+
+	u2, err := g.NewV4Context(context.Background())
+	require.ErrorIs(t, err, ErrDegenerateRandom)
+	assert.Equal(t, Nil, u2)
+}
+
+func TestNewV4WithoutNilSafe(t *testing.T) {
+	g := newRFC4122Generator()
+	g.rand = constReader(0x00)
+
+	u1, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, V4, u1.Version())
+}
+
 func BenchmarkNewV4(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = NewV4()
 	}
 }
 
+func BenchmarkNewV4Context(b *testing.B) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b.Run("NewV4", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = NewV4()
+			}
+		})
+	})
+	b.Run("NewV4Context", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_, _ = NewV4Context(ctx)
+			}
+		})
+	})
+}
+
 func TestNewV5(t *testing.T) {
 	u1 := NewV5(NamespaceDNS, "www.example.com")
 	assert.Equal(t, V5, u1.Version())
@@ -233,6 +507,63 @@ func TestNewV5(t *testing.T) {
 	assert.NotEqual(t, u3, u4)
 }
 
+func TestNewV3Bytes(t *testing.T) {
+	u1 := NewV3Bytes(NamespaceDNS, []byte("www.example.com"))
+	assert.Equal(t, NewV3(NamespaceDNS, "www.example.com"), u1)
+	assert.Equal(t, "5df41881-3aed-3515-88a7-2f4a814cf09e", u1.String())
+}
+
+func TestNewV5Bytes(t *testing.T) {
+	u1 := NewV5Bytes(NamespaceDNS, []byte("www.example.com"))
+	assert.Equal(t, NewV5(NamespaceDNS, "www.example.com"), u1)
+	assert.Equal(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2", u1.String())
+}
+
+func TestNewV8Hash(t *testing.T) {
+	u1 := NewV8Hash(NamespaceDNS, "www.example.com", sha256.New)
+	assert.Equal(t, V8, u1.Version())
+	assert.Equal(t, VariantRFC4122, u1.Variant())
+	assert.Equal(t, "5c146b14-3c52-8afd-938a-375d0df1fbf6", u1.String())
+
+	u2 := NewV8Hash(NamespaceDNS, "www.example.com", sha512.New)
+	assert.Equal(t, "94ee4ddb-9f36-8018-9ccf-86a4441691e0", u2.String())
+	assert.Equal(t, u2, NewV8Hash(NamespaceDNS, "www.example.com", sha512.New))
+	assert.NotEqual(t, u2, NewV8Hash(NamespaceURL, "www.example.com", sha512.New))
+
+	assert.Panics(t, func() {
+		NewV8Hash(NamespaceDNS, "www.example.com", func() hash.Hash { return fnv.New64a() })
+	})
+}
+
+func TestDerive(t *testing.T) {
+	assert.Equal(t, NamespaceDNS, Derive(NamespaceDNS))
+	assert.Equal(t, NewV5(NamespaceDNS, "tenant"), Derive(NamespaceDNS, "tenant"))
+
+	u1 := Derive(NamespaceDNS, "tenant", "project", "resource")
+	assert.Equal(t, NewV5(NewV5(NewV5(NamespaceDNS, "tenant"), "project"), "resource"), u1)
+	assert.Equal(t, V5, u1.Version())
+	assert.NotEqual(t, u1, Derive(NamespaceDNS, "tenant", "resource", "project"))
+}
+
+func TestNameWriter(t *testing.T) {
+	w3 := NewV3Writer(NamespaceDNS)
+	fmt.Fprint(w3, "www.", "example.com")
+	assert.Equal(t, NewV3(NamespaceDNS, "www.example.com"), w3.UUID())
+
+	w5 := NewV5Writer(NamespaceDNS)
+	_, err := io.Copy(w5, bytes.NewBufferString("www.example.com"))
+	require.NoError(t, err)
+	assert.Equal(t, NewV5(NamespaceDNS, "www.example.com"), w5.UUID())
+	assert.Equal(t, w5.UUID(), w5.UUID())
+}
+
+func BenchmarkNewV5Bytes(b *testing.B) {
+	name := []byte("www.example.com")
+	for i := 0; i < b.N; i++ {
+		NewV5Bytes(NamespaceDNS, name)
+	}
+}
+
 func BenchmarkNewV5(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		NewV5(NamespaceDNS, "www.example.com")
@@ -271,8 +602,91 @@ func TestNewV7(t *testing.T) {
 	assert.NotEqual(t, u1[6:], u2[6:])
 }
 
+func TestNewV7Context(t *testing.T) {
+	u1, err := NewV7Context(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, V7, u1.Version())
+	assert.Equal(t, VariantRFC4122, u1.Variant())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	u2, err := NewV7Context(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, Nil, u2)
+}
+
+func TestNewV7Monotonic(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionIncrement, now, now.Add(-time.Second), now.Add(time.Second))
+	WithMonotonicV7()(g)
+
+	u1, err := g.NewV7()
+	require.NoError(t, err)
+	u2, err := g.NewV7()
+	require.NoError(t, err)
+	u3, err := g.NewV7()
+	require.NoError(t, err)
+
+	assert.Equal(t, u1[:6], u2[:6])
+	assert.True(t, bytes.Compare(u2[:6], u3[:6]) < 0)
+}
+
+func TestNewV7WithoutMonotonic(t *testing.T) {
+	now := time.Now()
+	g := newClockGenerator(ClockRegressionIncrement, now, now.Add(-time.Second))
+
+	u1, err := g.NewV7()
+	require.NoError(t, err)
+	u2, err := g.NewV7()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.Compare(u1[:6], u2[:6]) > 0)
+}
+
+func TestNewV7MonotonicClock(t *testing.T) {
+	g := NewGenerator(WithMonotonicClockV7())
+
+	before := time.Now().Truncate(time.Millisecond)
+	u1, err := g.NewV7()
+	require.NoError(t, err)
+	u2, err := g.NewV7()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.Compare(u1[:6], u2[:6]) <= 0)
+	ts, err := TimestampFromV7(u1)
+	require.NoError(t, err)
+	assert.False(t, ts.Before(before))
+}
+
 func BenchmarkNewV7(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = NewV7()
 	}
 }
+
+func TestNewV6Layout(t *testing.T) {
+	// Golden values of RFC 9562 layout, with node and clock sequence
+	// of RFC 9562 test vector, A.5, which is the first one.
+	tests := []struct {
+		time     time.Time
+		expected string
+	}{
+		{time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC), "1ec9414c-232a-6b00-b3c8-9f6bdeced846"},
+		{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), "1b21dd21-3814-6000-b3c8-9f6bdeced846"},
+		{time.Date(2000, 1, 1, 0, 0, 0, 123456000, time.UTC), "1d3bfde6-3c2d-6680-b3c8-9f6bdeced846"},
+		{time.Date(2100, 12, 31, 23, 59, 59, 0, time.UTC), "244fc282-dd4e-6980-b3c8-9f6bdeced846"},
+	}
+	for _, tt := range tests {
+		g := newClockGenerator(ClockRegressionIncrement, tt.time)
+		g.hwAddrFunc = func() (net.HardwareAddr, error) { return vectors.Node, nil }
+		g.rand = bytes.NewReader(vectors.ClockSeq)
+		u, err := g.NewV6()
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, u.String())
+
+		ts, err := timeOf(u)
+		require.NoError(t, err)
+		assert.True(t, tt.time.Equal(ts), tt.expected)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrGlobalLocked is returned by ConfigureGlobal after LockGlobal
+// has been called.
+var ErrGlobalLocked = errors.New("uuid: global generator is locked")
+
+var (
+	globalGen    atomic.Pointer[rfc4122Generator]
+	globalMutex  sync.Mutex
+	globalLocked bool
+
+	// Version of UUIDs returned by New.
+	defaultVersion atomic.Uint32
+)
+
+func init() {
+	defaultVersion.Store(uint32(V4))
+	globalGen.Store(NewGenerator(defaultGlobalOptions()...).(*rfc4122Generator))
+}
+
+// Returns generator used by package-level functions.
+func global() *rfc4122Generator {
+	return globalGen.Load()
+}
+
+// ConfigureGlobal replaces generator used by package-level functions,
+// such as NewV4, with a new one configured with given options, applied
+// after the default ones.
+// It will return ErrGlobalLocked if LockGlobal has been called.
+func ConfigureGlobal(opts ...GeneratorOption) error {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if globalLocked {
+		return ErrGlobalLocked
+	}
+	opts = append(defaultGlobalOptions(), opts...)
+	globalGen.Store(NewGenerator(opts...).(*rfc4122Generator))
+	return nil
+}
+
+// LockGlobal freezes configuration of generator used by package-level
+// functions. Applications call it after their setup, so that libraries
+// can't silently replace the global generator or its random source.
+// There's no way to unlock it.
+func LockGlobal() {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	globalLocked = true
+}
+
+// IsGlobalLocked returns true if LockGlobal has been called.
+func IsGlobalLocked() bool {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	return globalLocked
+}
+
+// SetDefaultVersion sets version of UUIDs returned by New, which is
+// V4 initially. Supported versions are V4 and V7, so that deployments
+// can switch to time ordered UUIDs without touching every caller.
+// It will return ErrGlobalLocked if LockGlobal has been called.
+func SetDefaultVersion(version byte) error {
+	if version != V4 && version != V7 {
+		return fmt.Errorf("uuid: unsupported default version %d", version)
+	}
+
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	if globalLocked {
+		return ErrGlobalLocked
+	}
+	defaultVersion.Store(uint32(version))
+	return nil
+}
+
+// DefaultVersion returns version of UUIDs returned by New.
+func DefaultVersion() byte {
+	return byte(defaultVersion.Load())
+}
+
+// New returns UUID of the default version, set with SetDefaultVersion.
+// It never panics: same as NewV4OrNil and NewV7OrNil, it returns
+// a Nil UUID if the random source fails.
+func New() UUID {
+	if DefaultVersion() == V7 {
+		return NewV7OrNil()
+	}
+	return NewV4OrNil()
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+// Restores state of global generator after test.
+func resetGlobal(t *testing.T) {
+	g := global()
+	version := defaultVersion.Load()
+	t.Cleanup(func() {
+		globalMutex.Lock()
+		defer globalMutex.Unlock()
+
+		globalLocked = false
+		globalGen.Store(g)
+		defaultVersion.Store(version)
+	})
+}
+
+func TestConfigureGlobal(t *testing.T) {
+	resetGlobal(t)
+
+	err := ConfigureGlobal(WithRandReader(constReader(0x42)))
+	require.NoError(t, err)
+
+	u1, err := NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, "42424242-4242-4242-8242-424242424242", u1.String())
+}
+
+func TestLockGlobal(t *testing.T) {
+	resetGlobal(t)
+
+	assert.False(t, IsGlobalLocked())
+	LockGlobal()
+	assert.True(t, IsGlobalLocked())
+
+	g := global()
+	err := ConfigureGlobal(WithRandReader(constReader(0x42)))
+	assert.ErrorIs(t, err, ErrGlobalLocked)
+	assert.Same(t, g, global())
+}
+
+func TestNewV4OrNil(t *testing.T) {
+	resetGlobal(t)
+
+	u1 := NewV4OrNil()
+	assert.Equal(t, V4, u1.Version())
+
+	err := ConfigureGlobal(WithRandReader(&faultyReader{}))
+	require.NoError(t, err)
+	assert.Equal(t, Nil, NewV4OrNil())
+}
+
+func TestNewV7OrNil(t *testing.T) {
+	resetGlobal(t)
+
+	u1 := NewV7OrNil()
+	assert.Equal(t, V7, u1.Version())
+
+	err := ConfigureGlobal(WithRandReader(&faultyReader{}))
+	require.NoError(t, err)
+	assert.Equal(t, Nil, NewV7OrNil())
+}
+
+func TestNew(t *testing.T) {
+	resetGlobal(t)
+
+	assert.Equal(t, V4, DefaultVersion())
+	assert.Equal(t, V4, New().Version())
+
+	require.NoError(t, SetDefaultVersion(V7))
+	assert.Equal(t, V7, DefaultVersion())
+	u := New()
+	assert.Equal(t, V7, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+
+	assert.Error(t, SetDefaultVersion(V1))
+	assert.Equal(t, V7, DefaultVersion())
+
+	LockGlobal()
+	assert.Equal(t, ErrGlobalLocked, SetDefaultVersion(V4))
+	assert.Equal(t, V7, DefaultVersion())
+}
+
+func TestNewFaultyRand(t *testing.T) {
+	resetGlobal(t)
+
+	require.NoError(t, ConfigureGlobal(WithRandReader(&faultyReader{})))
+	assert.Equal(t, Nil, New())
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// LegacyGUID is a GUID laid out as the Windows GUID structure, whose
+// first three fields are integers stored in native, little endian byte
+// order, while the rest is a byte array. Such layout is produced by
+// .NET Guid.ToByteArray, stored by SQL Server in uniqueidentifier
+// columns and used by Microsoft variant GUIDs, e.g. of COM interfaces.
+// Text representation is the same as of UUID.
+type LegacyGUID struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// GUIDFromUUID returns GUID with fields of u.
+func GUIDFromUUID(u UUID) LegacyGUID {
+	g := LegacyGUID{
+		Data1: binary.BigEndian.Uint32(u[0:]),
+		Data2: binary.BigEndian.Uint16(u[4:]),
+		Data3: binary.BigEndian.Uint16(u[6:]),
+	}
+	copy(g.Data4[:], u[8:])
+	return g
+}
+
+// GUIDFromBytes returns GUID converted from its 16 bytes in the layout
+// of Windows GUID structure. It will return error if the slice isn't
+// 16 bytes long.
+func GUIDFromBytes(input []byte) (LegacyGUID, error) {
+	if len(input) != Size {
+		return LegacyGUID{}, fmt.Errorf("uuid: expected %d bytes, got %d bytes", Size, len(input))
+	}
+	g := LegacyGUID{
+		Data1: binary.LittleEndian.Uint32(input[0:]),
+		Data2: binary.LittleEndian.Uint16(input[4:]),
+		Data3: binary.LittleEndian.Uint16(input[6:]),
+	}
+	copy(g.Data4[:], input[8:])
+	return g, nil
+}
+
+// UUID returns UUID with fields of GUID, which has the same text
+// representation.
+func (g LegacyGUID) UUID() UUID {
+	u := UUID{}
+	binary.BigEndian.PutUint32(u[0:], g.Data1)
+	binary.BigEndian.PutUint16(u[4:], g.Data2)
+	binary.BigEndian.PutUint16(u[6:], g.Data3)
+	copy(u[8:], g.Data4[:])
+	return u
+}
+
+// Bytes returns 16 bytes of GUID in the layout of Windows GUID structure.
+func (g LegacyGUID) Bytes() []byte {
+	b := g.layout()
+	return b[:]
+}
+
+// Returns 16 bytes of GUID in the layout of Windows GUID structure.
+func (g LegacyGUID) layout() [Size]byte {
+	var b [Size]byte
+	binary.LittleEndian.PutUint32(b[0:], g.Data1)
+	binary.LittleEndian.PutUint16(b[4:], g.Data2)
+	binary.LittleEndian.PutUint16(b[6:], g.Data3)
+	copy(b[8:], g.Data4[:])
+	return b
+}
+
+// String returns canonical string representation of GUID.
+func (g LegacyGUID) String() string {
+	return g.UUID().String()
+}
+
+// Time returns timestamp of GUID holding version 1 time-based layout in
+// its fields, regardless of its variant. Unlike Inspect, which only
+// interprets RFC 4122 variant UUIDs, it's meant for GUIDs generated by
+// legacy Windows APIs, which may be marked with Microsoft variant.
+// It will return error for GUIDs of any other version.
+func (g LegacyGUID) Time() (time.Time, error) {
+	if v := byte(g.Data3 >> 12); v != V1 {
+		return time.Time{}, fmt.Errorf("uuid: expected version %d, got version %d", V1, v)
+	}
+	return timeOf(g.UUID())
+}
+
+// Node returns node ID stored in the last six bytes of GUID, which is
+// meaningful for GUIDs holding version 1 time-based layout.
+func (g LegacyGUID) Node() net.HardwareAddr {
+	return append(net.HardwareAddr(nil), g.Data4[2:]...)
+}
+
+// Order in which SQL Server compares bytes of uniqueidentifier values
+// in the layout of Windows GUID structure, as SqlGuid does.
+var sqlGUIDOrder = [Size]int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3}
+
+// CompareGUID compares two GUIDs in the order SQL Server sorts
+// uniqueidentifier values: by the last six bytes first, followed by
+// Data4[0:2], Data3, Data2 and Data1, where the bytes of Data1, Data2
+// and Data3 are compared least significant first, as they are stored.
+// The result is -1 if a < b, 0 if a == b and +1 if a > b.
+func CompareGUID(a, b LegacyGUID) int {
+	la, lb := a.layout(), b.layout()
+	for _, i := range sqlGUIDOrder {
+		switch {
+		case la[i] < lb[i]:
+			return -1
+		case la[i] > lb[i]:
+			return 1
+		}
+	}
+	return 0
+}
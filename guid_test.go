@@ -0,0 +1,122 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+// IUnknown interface ID, a Microsoft variant GUID.
+var iidIUnknown = Must(FromString("00000000-0000-0000-c000-000000000046"))
+
+func TestLegacyGUID(t *testing.T) {
+	g := GUIDFromUUID(NamespaceDNS)
+	assert.Equal(t, LegacyGUID{
+		Data1: 0x6ba7b810,
+		Data2: 0x9dad,
+		Data3: 0x11d1,
+		Data4: [8]byte{0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+	}, g)
+	assert.Equal(t, NamespaceDNS, g.UUID())
+	assert.Equal(t, NamespaceDNS.String(), g.String())
+
+	// Byte order of .NET Guid.ToByteArray.
+	data := []byte{0x10, 0xb8, 0xa7, 0x6b, 0xad, 0x9d, 0xd1, 0x11, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	assert.Equal(t, data, g.Bytes())
+
+	g2, err := GUIDFromBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, g, g2)
+
+	_, err = GUIDFromBytes(data[1:])
+	assert.Error(t, err)
+}
+
+func TestLegacyGUIDTime(t *testing.T) {
+	var node [6]byte
+	copy(node[:], vectors.Node)
+	u := Must(NewV1At(vectors.Time, 0x33c8, node))
+
+	// Legacy GUID of Microsoft variant holding version 1 layout.
+	u.SetVariant(VariantMicrosoft)
+	assert.True(t, Inspect(u).Time.IsZero())
+
+	g := GUIDFromUUID(u)
+	ts, err := g.Time()
+	require.NoError(t, err)
+	assert.True(t, vectors.Time.Equal(ts))
+	assert.Equal(t, net.HardwareAddr(vectors.Node), g.Node())
+
+	// Read from bytes in RFC layout, fields come out scrambled.
+	g2, err := GUIDFromBytes(u.Bytes())
+	require.NoError(t, err)
+	_, err = g2.Time()
+	assert.Error(t, err)
+
+	_, err = GUIDFromUUID(iidIUnknown).Time()
+	assert.Error(t, err)
+}
+
+func TestCompareGUID(t *testing.T) {
+	ids := []LegacyGUID{
+		GUIDFromUUID(Must(FromString("00000000-0000-0000-0000-000000000001"))),
+		GUIDFromUUID(Must(FromString("00000000-0000-0000-0001-000000000000"))),
+		GUIDFromUUID(Must(FromString("00000000-0000-0001-0000-000000000000"))),
+		GUIDFromUUID(Must(FromString("00000000-0001-0000-0000-000000000000"))),
+		GUIDFromUUID(Must(FromString("00000001-0000-0000-0000-000000000000"))),
+	}
+	sorted := slices.Clone(ids)
+	slices.Reverse(sorted)
+	slices.SortFunc(sorted, CompareGUID)
+	assert.Equal(t, []LegacyGUID{ids[4], ids[3], ids[2], ids[1], ids[0]}, sorted)
+
+	assert.Equal(t, 0, CompareGUID(ids[0], ids[0]))
+	assert.Equal(t, 1, CompareGUID(ids[0], ids[1]))
+	assert.Equal(t, -1, CompareGUID(ids[4], ids[3]))
+
+	// Bytes of Data1, Data2 and Data3 are compared least significant
+	// first, while those of Data4 are compared in order.
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"00000001-0000-0000-0000-000000000000", "01000000-0000-0000-0000-000000000000", 1},
+		{"000000ff-0000-0000-0000-000000000000", "00000100-0000-0000-0000-000000000000", 1},
+		{"00000000-0001-0000-0000-000000000000", "00000000-0100-0000-0000-000000000000", 1},
+		{"00000000-0000-00ff-0000-000000000000", "00000000-0000-0100-0000-000000000000", 1},
+		{"00000000-0000-0000-0001-000000000000", "00000000-0000-0000-0100-000000000000", -1},
+		{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-010000000000", -1},
+		{"ffffffff-ffff-ffff-0000-000000000000", "00000000-0000-0000-0000-000000000001", -1},
+	}
+	for _, tt := range tests {
+		a := GUIDFromUUID(Must(FromString(tt.a)))
+		b := GUIDFromUUID(Must(FromString(tt.b)))
+		assert.Equal(t, tt.expected, CompareGUID(a, b), tt.a, tt.b)
+		assert.Equal(t, -tt.expected, CompareGUID(b, a), tt.b, tt.a)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Multiplier used to combine halves of UUID, taken from xxHash64.
+const hashPrime = 0x9e3779b97f4a7c15
+
+// Hash64 returns 64 bits hash of UUID, suitable for bloom filters,
+// hash maps with custom hashers and consistent hashing rings.
+// Unlike truncating UUID, which keeps mostly timestamp bits of V1, V6
+// and V7 UUIDs, it mixes all 128 bits: the second half is multiplied
+// and rotated into the first one, and the result goes through
+// MurmurHash3 fmix64 finalizer, so that every input bit affects every
+// output bit. The hash is stable across releases and platforms, but
+// isn't cryptographic and must not be used against adversarial inputs.
+func (u UUID) Hash64() uint64 {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	return fmix64(hi ^ bits.RotateLeft64(lo*hashPrime, 31))
+}
+
+// Hash32 returns 32 bits hash of UUID, which is Hash64 with its halves
+// folded together by XOR. Same caveats as for Hash64 apply.
+func (u UUID) Hash32() uint32 {
+	h := u.Hash64()
+	return uint32(h ^ h>>32)
+}
+
+// MurmurHash3 64 bits finalizer.
+func fmix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
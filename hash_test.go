@@ -0,0 +1,96 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+func TestHash64(t *testing.T) {
+	assert.Equal(t, NamespaceDNS.Hash64(), NamespaceDNS.Hash64())
+	assert.NotEqual(t, NamespaceDNS.Hash64(), NamespaceURL.Hash64())
+
+	// Hash is part of API and must not change between releases.
+	tests := []struct {
+		u      UUID
+		hash64 uint64
+		hash32 uint32
+	}{
+		{Nil, 0x0000000000000000, 0x00000000},
+		{NamespaceDNS, 0x5537b02d4f91df32, 0x1aa66f1f},
+		{NamespaceURL, 0xd8cae1d4c6b2ca4f, 0x1e782b9b},
+		{MustFromString("ffffffff-ffff-4fff-bfff-ffffffffffff"), 0xaaf7c0e2c94740a4, 0x63b08046},
+		{Max, 0x2df368c820ffc1c9, 0x0d0ca901},
+		{MustFromString("01890a5d-ac96-774b-bcce-b302099a8057"), 0xfdda23ec927f367e, 0x6fa51592},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.hash64, tt.u.Hash64(), tt.u)
+		assert.Equal(t, tt.hash32, tt.u.Hash32(), tt.u)
+	}
+}
+
+func TestHash64Avalanche(t *testing.T) {
+	// Flipping any input bit should flip about half of output bits.
+	base := NamespaceDNS
+	h := base.Hash64()
+	total := 0
+	for i := 0; i < Size*8; i++ {
+		u := base
+		u[i/8] ^= 1 << (i % 8)
+		flipped := bits.OnesCount64(h ^ u.Hash64())
+		assert.True(t, flipped > 8, "bit %d flipped only %d bits", i, flipped)
+		total += flipped
+	}
+	avg := float64(total) / (Size * 8)
+	assert.InDelta(t, 32, avg, 4)
+}
+
+func TestHash64V7Distribution(t *testing.T) {
+	// UUIDs generated in the same millisecond share timestamp prefix,
+	// yet their hashes must spread evenly over buckets.
+	const buckets = 16
+	var counts [buckets]int
+	g := NewGenerator()
+	for i := 0; i < 16000; i++ {
+		u, err := g.NewV7()
+		assert.NoError(t, err)
+		counts[u.Hash32()%buckets]++
+	}
+	for i, c := range counts {
+		assert.InDelta(t, 1000, c, 200, "bucket %d", i)
+	}
+}
+
+func TestHash32(t *testing.T) {
+	h := NamespaceDNS.Hash64()
+	assert.Equal(t, uint32(h^h>>32), NamespaceDNS.Hash32())
+}
+
+func BenchmarkHash64(b *testing.B) {
+	u := NamespaceDNS
+	for i := 0; i < b.N; i++ {
+		_ = u.Hash64()
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import "hash/maphash"
+
+// Hasher hashes UUIDs with a random seed using hash/maphash, so that
+// hashes are unpredictable to outside parties and vary between processes.
+// Its Hash method has the func(UUID) uint64 shape expected by generic
+// hash map libraries, e.g. swiss tables, accepting custom hashers.
+// Zero value is not usable, construct it with NewHasher or
+// NewHasherWithSeed. Hasher is safe for concurrent use.
+type Hasher struct {
+	seed maphash.Seed
+}
+
+// NewHasher returns Hasher with a new random seed.
+func NewHasher() Hasher {
+	return Hasher{seed: maphash.MakeSeed()}
+}
+
+// NewHasherWithSeed returns Hasher using given seed, so that hashes
+// agree with other maphash users sharing the same seed.
+func NewHasherWithSeed(seed maphash.Seed) Hasher {
+	return Hasher{seed: seed}
+}
+
+// Seed returns seed used by Hasher.
+func (h Hasher) Seed() maphash.Seed {
+	return h.seed
+}
+
+// Hash returns hash of UUID.
+// Hashes are equal for equal UUIDs and the same seed.
+func (h Hasher) Hash(u UUID) uint64 {
+	return maphash.Bytes(h.seed, u[:])
+}
+
+// WriteHash writes UUID into maphash.Hash, for hashing composite
+// keys containing UUIDs.
+func WriteHash(h *maphash.Hash, u UUID) {
+	_, _ = h.Write(u[:])
+}
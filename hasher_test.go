@@ -0,0 +1,54 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"hash/maphash"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+func TestHasher(t *testing.T) {
+	h := NewHasher()
+	assert.Equal(t, h.Hash(NamespaceDNS), h.Hash(NamespaceDNS))
+	assert.NotEqual(t, h.Hash(NamespaceDNS), h.Hash(NamespaceURL))
+
+	h2 := NewHasherWithSeed(h.Seed())
+	assert.Equal(t, h.Hash(NamespaceDNS), h2.Hash(NamespaceDNS))
+
+	var mh maphash.Hash
+	mh.SetSeed(h.Seed())
+	WriteHash(&mh, NamespaceDNS)
+	assert.Equal(t, h.Hash(NamespaceDNS), mh.Sum64())
+
+	var hash func(UUID) uint64 = h.Hash
+	assert.Equal(t, h.Hash(Nil), hash(Nil))
+}
+
+func BenchmarkHasher(b *testing.B) {
+	h := NewHasher()
+	u := NamespaceDNS
+	for i := 0; i < b.N; i++ {
+		_ = h.Hash(u)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package httpuuid provides helpers for carrying UUIDs in HTTP requests:
+// parsing them from headers, a middleware which assigns request IDs and
+// passing them through context.
+package httpuuid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// HeaderRequestID is the name of header carrying request ID.
+const HeaderRequestID = "X-Request-ID"
+
+// contextKey is the type of context key, unexported
+// to prevent collisions with other packages.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying u.
+func NewContext(ctx context.Context, u uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns UUID carried by ctx, if any.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	u, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return u, ok
+}
+
+// ParseHeader returns UUID parsed from header of request r with given name.
+// Header value is expected in a form accepted by uuid.FromString.
+func ParseHeader(r *http.Request, name string) (uuid.UUID, error) {
+	value := r.Header.Get(name)
+	if value == "" {
+		return uuid.Nil, fmt.Errorf("uuid: missing %s header", name)
+	}
+	return uuid.FromString(value)
+}
+
+// RequestID returns a middleware ensuring that X-Request-ID header of
+// request contains a valid version 7 UUID. Absent or invalid request ID
+// is replaced with a newly generated one. The request ID is also set on
+// the response and carried by the request context, see FromContext.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := ParseHeader(r, HeaderRequestID)
+		if err != nil || id.Version() != uuid.V7 || id.Variant() != uuid.VariantRFC4122 {
+			if id, err = uuid.NewV7(); err != nil {
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		value := id.String()
+		r = r.WithContext(NewContext(r.Context(), id))
+		r.Header.Set(HeaderRequestID, value)
+		w.Header().Set(HeaderRequestID, value)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package httpuuid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := NewContext(context.Background(), uuid.NamespaceDNS)
+	u, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, uuid.NamespaceDNS, u)
+}
+
+func TestParseHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := ParseHeader(r, "X-Trace-ID")
+	assert.Error(t, err)
+
+	r.Header.Set("X-Trace-ID", "6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	u, err := ParseHeader(r, "X-Trace-ID")
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS, u)
+
+	r.Header.Set("X-Trace-ID", "invalid")
+	_, err = ParseHeader(r, "X-Trace-ID")
+	assert.Error(t, err)
+}
+
+func TestRequestID(t *testing.T) {
+	var seen uuid.UUID
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := FromContext(r.Context())
+		require.True(t, ok)
+		assert.Equal(t, u.String(), r.Header.Get(HeaderRequestID))
+		seen = u
+	}))
+
+	v7 := uuid.Must(uuid.NewV7())
+	tests := []struct {
+		header string
+		keep   bool
+	}{
+		{"", false},
+		{"invalid", false},
+		{uuid.NamespaceDNS.String(), false},
+		{v7.String(), true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set(HeaderRequestID, tt.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, uuid.V7, seen.Version(), tt.header)
+		assert.Equal(t, seen.String(), w.Header().Get(HeaderRequestID))
+		if tt.keep {
+			assert.Equal(t, v7, seen)
+		} else {
+			assert.NotEqual(t, tt.header, seen.String())
+		}
+	}
+}
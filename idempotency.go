@@ -0,0 +1,69 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// IdempotencyKey returns version 5 UUID derived from namespace UUID and
+// canonicalized HTTP request, to be used as a de-duplication key. Services
+// following the same recipe derive the same key for the same request:
+//
+//  1. method is uppercased;
+//  2. path is cleaned, as by path.Clean, and made absolute;
+//  3. query parameters, if any, are sorted by key, keeping the order
+//     of values of the same key;
+//  4. body is hashed with SHA-256;
+//  5. the name "METHOD\nPATH?QUERY\nHEX(SHA-256(body))" is hashed with
+//     namespace UUID as in NewV5.
+func IdempotencyKey(ns UUID, method, path string, body []byte) UUID {
+	sum := sha256.Sum256(body)
+
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(method))
+	b.WriteByte('\n')
+	b.WriteString(canonicalRequestPath(path))
+	b.WriteByte('\n')
+	b.WriteString(hex.EncodeToString(sum[:]))
+
+	return NewV5(ns, b.String())
+}
+
+// Returns request path with cleaned path and sorted query parameters.
+func canonicalRequestPath(p string) string {
+	p, query, hasQuery := strings.Cut(p, "?")
+	p = path.Clean("/" + p)
+	if !hasQuery || query == "" {
+		return p
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		// Keep malformed query as is, it's still deterministic.
+		return p + "?" + query
+	}
+	return p + "?" + values.Encode()
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	body := []byte(`{"amount":100}`)
+	u := IdempotencyKey(NamespaceURL, "POST", "/v1/payments?b=2&a=1", body)
+	assert.Equal(t, V5, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+
+	// Equivalent requests.
+	assert.Equal(t, u, IdempotencyKey(NamespaceURL, "post", "/v1/payments?b=2&a=1", body))
+	assert.Equal(t, u, IdempotencyKey(NamespaceURL, "POST", "v1//payments/?a=1&b=2", body))
+	assert.Equal(t, u, IdempotencyKey(NamespaceURL, "POST", "/v1/x/../payments?a=1&b=2", body))
+
+	// Different requests.
+	assert.NotEqual(t, u, IdempotencyKey(NamespaceDNS, "POST", "/v1/payments?a=1&b=2", body))
+	assert.NotEqual(t, u, IdempotencyKey(NamespaceURL, "PUT", "/v1/payments?a=1&b=2", body))
+	assert.NotEqual(t, u, IdempotencyKey(NamespaceURL, "POST", "/v1/payments?a=1&b=3", body))
+	assert.NotEqual(t, u, IdempotencyKey(NamespaceURL, "POST", "/v1/payments", body))
+	assert.NotEqual(t, u, IdempotencyKey(NamespaceURL, "POST", "/v1/payments?a=1&b=2", []byte(`{"amount":101}`)))
+
+	// Recipe is part of API, so keys must not change.
+	assert.Equal(t, NewV5(NamespaceURL, "POST\n/v1/payments?a=1&b=2\n"+
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+		IdempotencyKey(NamespaceURL, "POST", "/v1/payments?a=1&b=2", nil))
+}
+
+func TestCanonicalRequestPath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"", "/"},
+		{"/", "/"},
+		{"/a/b/", "/a/b"},
+		{"a/./b", "/a/b"},
+		{"/a?", "/a"},
+		{"/a?z=1&y=2&z=0", "/a?y=2&z=1&z=0"},
+		{"/a?%zz", "/a?%zz"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, canonicalRequestPath(tt.input), tt.input)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"sync"
+)
+
+// NewInsecureGenerator returns a Generator drawing random bits from
+// math/rand/v2 ChaCha8 source seeded with seed instead of crypto/rand.
+// Generators with the same seed produce the same random bits, which makes
+// them a fit for simulations and test data, where it's also much faster.
+//
+// UUIDs produced by this generator are predictable and MUST NOT be used
+// where unguessable identifiers are required.
+func NewInsecureGenerator(seed uint64, opts ...GeneratorOption) Generator {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	return NewDeterministicV4(key, opts...)
+}
+
+// NewDeterministicV4 returns a Generator drawing random bits from
+// math/rand/v2 ChaCha8 stream keyed with seed. It is meant for
+// property-based tests: a failing case can be replayed by creating
+// the generator again with the reported seed, which reproduces the exact
+// sequence of version 4 UUIDs. Time-based UUIDs still depend on the clock.
+//
+// Same as NewInsecureGenerator, its output is predictable and MUST NOT
+// be used where unguessable identifiers are required.
+func NewDeterministicV4(seed [32]byte, opts ...GeneratorOption) Generator {
+	g := newRFC4122Generator()
+	g.rand = &lockedReader{r: rand.NewChaCha8(seed)}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// lockedReader serializes reads from ChaCha8, which isn't safe
+// for concurrent use.
+type lockedReader struct {
+	mu sync.Mutex
+	r  *rand.ChaCha8
+}
+
+func (r *lockedReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Read(p)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestNewInsecureGenerator(t *testing.T) {
+	g := NewInsecureGenerator(42)
+	u1, err := g.NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, V4, u1.Version())
+	assert.Equal(t, VariantRFC4122, u1.Variant())
+
+	u2, err := g.NewV4()
+	require.NoError(t, err)
+	assert.NotEqual(t, u1, u2)
+
+	u3, err := NewInsecureGenerator(42).NewV4()
+	require.NoError(t, err)
+	assert.Equal(t, u1, u3)
+
+	u4, err := NewInsecureGenerator(43).NewV4()
+	require.NoError(t, err)
+	assert.NotEqual(t, u1, u4)
+}
+
+func TestNewInsecureGeneratorV7(t *testing.T) {
+	u, err := NewInsecureGenerator(42).NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, V7, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+}
+
+func TestNewDeterministicV4(t *testing.T) {
+	seed := [32]byte{1, 2, 3}
+	replay := func(g Generator) []UUID {
+		res := make([]UUID, 10)
+		for i := range res {
+			u, err := g.NewV4()
+			require.NoError(t, err)
+			res[i] = u
+		}
+		return res
+	}
+
+	s1 := replay(NewDeterministicV4(seed))
+	assert.Equal(t, s1, replay(NewDeterministicV4(seed)))
+	assert.NotEqual(t, s1, replay(NewDeterministicV4([32]byte{1, 2, 4})))
+	for _, u := range s1 {
+		assert.Equal(t, V4, u.Version())
+	}
+
+	var key [32]byte
+	key[0] = 42
+	assert.Equal(t, replay(NewInsecureGenerator(42)), replay(NewDeterministicV4(key)))
+}
+
+func BenchmarkInsecureGeneratorNewV4(b *testing.B) {
+	g := NewInsecureGenerator(42)
+	for i := 0; i < b.N; i++ {
+		_, _ = g.NewV4()
+	}
+}
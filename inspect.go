@@ -0,0 +1,116 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// Info describes fields of UUID, as reported by Inspect.
+type Info struct {
+	UUID    UUID
+	Version byte
+	Variant byte
+	// Time is timestamp of time-based (version 1, 6 or 7) UUID,
+	// zero time otherwise.
+	Time time.Time
+	// Node is node ID of version 1, 2 or 6 UUID, nil otherwise.
+	Node net.HardwareAddr
+	// ClockSeq is clock sequence of version 1 or 6 UUID.
+	// It is meaningful only if HasClockSeq is true.
+	ClockSeq    uint16
+	HasClockSeq bool
+}
+
+// Inspect returns description of fields of UUID. Fields specific to
+// UUID versions are only reported for UUIDs of RFC 4122 variant.
+func Inspect(u UUID) Info {
+	info := Info{UUID: u, Version: u.Version(), Variant: u.Variant()}
+	if info.Variant != VariantRFC4122 {
+		return info
+	}
+
+	switch info.Version {
+	case V1, V6:
+		info.Time, _ = timeOf(u)
+		info.ClockSeq = binary.BigEndian.Uint16(u[8:]) & 0x3fff
+		info.HasClockSeq = true
+		info.Node = append(net.HardwareAddr(nil), u[10:]...)
+	case V2:
+		info.Node = append(net.HardwareAddr(nil), u[10:]...)
+	case V7:
+		info.Time, _ = timeOf(u)
+	}
+	return info
+}
+
+// infoJSON is stable JSON schema of Info.
+type infoJSON struct {
+	UUID     string  `json:"uuid"`
+	Version  byte    `json:"version"`
+	Variant  string  `json:"variant"`
+	Time     *string `json:"time,omitempty"`
+	Node     *string `json:"node,omitempty"`
+	ClockSeq *uint16 `json:"clock_seq,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The schema is
+// stable: "uuid", "version" and "variant" are always present, while
+// "time" (RFC 3339 in UTC), "node" (12 hex digits) and "clock_seq"
+// are present only if applicable to UUID version.
+func (info Info) MarshalJSON() ([]byte, error) {
+	v := infoJSON{
+		UUID:    info.UUID.String(),
+		Version: info.Version,
+		Variant: variantName(info.Variant),
+	}
+	if !info.Time.IsZero() {
+		t := info.Time.UTC().Format(time.RFC3339Nano)
+		v.Time = &t
+	}
+	if info.Node != nil {
+		node := hex.EncodeToString(info.Node)
+		v.Node = &node
+	}
+	if info.HasClockSeq {
+		v.ClockSeq = &info.ClockSeq
+	}
+	return json.Marshal(v)
+}
+
+// Returns name of UUID layout variant.
+func variantName(variant byte) string {
+	switch variant {
+	case VariantNCS:
+		return "ncs"
+	case VariantRFC4122:
+		return "rfc4122"
+	case VariantMicrosoft:
+		return "microsoft"
+	default:
+		return "future"
+	}
+}
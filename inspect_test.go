@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestInspect(t *testing.T) {
+	info := Inspect(NamespaceDNS)
+	assert.Equal(t, V1, info.Version)
+	assert.Equal(t, VariantRFC4122, info.Variant)
+	assert.Equal(t, net.HardwareAddr{0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, info.Node)
+	assert.True(t, info.HasClockSeq)
+	assert.Equal(t, uint16(0x00b4), info.ClockSeq)
+	assert.Equal(t, time.Date(1998, 2, 4, 22, 13, 53, 151182400, time.UTC), info.Time.UTC())
+
+	u7 := FromStringOrNil("01890a3e-4380-7000-8000-000000000000")
+	info = Inspect(u7)
+	assert.Equal(t, V7, info.Version)
+	assert.Equal(t, time.UnixMilli(0x01890a3e4380).UTC(), info.Time.UTC())
+	assert.Nil(t, info.Node)
+	assert.False(t, info.HasClockSeq)
+
+	info = Inspect(Must(NewV4()))
+	assert.Equal(t, V4, info.Version)
+	assert.True(t, info.Time.IsZero())
+
+	info = Inspect(Max)
+	assert.Equal(t, VariantFuture, info.Variant)
+	assert.True(t, info.Time.IsZero())
+	assert.Nil(t, info.Node)
+}
+
+func TestInfoMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Inspect(NamespaceDNS))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uuid": "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"version": 1,
+		"variant": "rfc4122",
+		"time": "1998-02-04T22:13:53.1511824Z",
+		"node": "00c04fd430c8",
+		"clock_seq": 180
+	}`, string(data))
+
+	data, err = json.Marshal(Inspect(FromStringOrNil("01890a3e-4380-7000-8000-000000000000")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"uuid": "01890a3e-4380-7000-8000-000000000000",
+		"version": 7,
+		"variant": "rfc4122",
+		"time": "2023-06-30T03:00:00Z"
+	}`, string(data))
+
+	data, err = json.Marshal(Inspect(Nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uuid": "00000000-0000-0000-0000-000000000000", "version": 0, "variant": "ncs"}`, string(data))
+
+	assert.Equal(t, "microsoft", variantName(VariantMicrosoft))
+	assert.Equal(t, "future", variantName(VariantFuture))
+}
@@ -0,0 +1,325 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package assert provides test assertions reporting failures without
+// stopping the test. It mirrors the subset of github.com/stretchr/testify
+// API used by tests of this module, which keeps the module free of
+// external dependencies.
+package assert
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Returns message of failed assertion: either the only argument,
+// or arguments formatted with the first one as format.
+func messageFromMsgAndArgs(msgAndArgs ...interface{}) string {
+	switch len(msgAndArgs) {
+	case 0:
+		return ""
+	case 1:
+		if msg, ok := msgAndArgs[0].(string); ok {
+			return msg
+		}
+		return fmt.Sprintf("%+v", msgAndArgs[0])
+	default:
+		return fmt.Sprintf(msgAndArgs[0].(string), msgAndArgs[1:]...)
+	}
+}
+
+// Fail reports failure of assertion and returns false.
+func Fail(t testing.TB, failure string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if msg := messageFromMsgAndArgs(msgAndArgs...); msg != "" {
+		t.Errorf("%s\nMessages: %s", failure, msg)
+	} else {
+		t.Errorf("%s", failure)
+	}
+	return false
+}
+
+// ObjectsAreEqual reports whether expected and actual are equal.
+// Byte slices are compared by content, other values by reflect.DeepEqual.
+func ObjectsAreEqual(expected, actual interface{}) bool {
+	exp, ok := expected.([]byte)
+	if !ok {
+		return reflect.DeepEqual(expected, actual)
+	}
+	act, ok := actual.([]byte)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(exp, act)
+}
+
+// Equal asserts that two objects are equal.
+func Equal(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !ObjectsAreEqual(expected, actual) {
+		return Fail(t, fmt.Sprintf("Not equal:\nexpected: %#v\nactual  : %#v", expected, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// NotEqual asserts that two objects aren't equal.
+func NotEqual(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if ObjectsAreEqual(expected, actual) {
+		return Fail(t, fmt.Sprintf("Should not be: %#v", actual), msgAndArgs...)
+	}
+	return true
+}
+
+// Same asserts that two pointers reference the same object.
+func Same(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if reflect.ValueOf(expected).Kind() != reflect.Pointer || expected != actual {
+		return Fail(t, fmt.Sprintf("Not same:\nexpected: %p %#v\nactual  : %p %#v", expected, expected, actual, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// True asserts that value is true.
+func True(t testing.TB, value bool, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !value {
+		return Fail(t, "Should be true", msgAndArgs...)
+	}
+	return true
+}
+
+// False asserts that value is false.
+func False(t testing.TB, value bool, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if value {
+		return Fail(t, "Should be false", msgAndArgs...)
+	}
+	return true
+}
+
+// Returns true if object is nil or a nil value of a nillable kind.
+func isNil(object interface{}) bool {
+	if object == nil {
+		return true
+	}
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map,
+		reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Nil asserts that object is nil.
+func Nil(t testing.TB, object interface{}, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !isNil(object) {
+		return Fail(t, fmt.Sprintf("Expected nil, but got: %#v", object), msgAndArgs...)
+	}
+	return true
+}
+
+// Returns true if object is nil, has zero length or is zero value.
+func isEmpty(object interface{}) bool {
+	if object == nil {
+		return true
+	}
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer:
+		return v.IsNil() || isEmpty(v.Elem().Interface())
+	}
+	return v.IsZero()
+}
+
+// Empty asserts that object is nil, has zero length or is zero value.
+func Empty(t testing.TB, object interface{}, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !isEmpty(object) {
+		return Fail(t, fmt.Sprintf("Should be empty, but was %#v", object), msgAndArgs...)
+	}
+	return true
+}
+
+// Len asserts that object has given length.
+func Len(t testing.TB, object interface{}, length int, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		if l := v.Len(); l != length {
+			return Fail(t, fmt.Sprintf("%#v should have %d item(s), but has %d", object, length, l), msgAndArgs...)
+		}
+		return true
+	}
+	return Fail(t, fmt.Sprintf("%#v has no length", object), msgAndArgs...)
+}
+
+// Contains asserts that s contains substr.
+func Contains(t testing.TB, s, substr string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !strings.Contains(s, substr) {
+		return Fail(t, fmt.Sprintf("%q does not contain %q", s, substr), msgAndArgs...)
+	}
+	return true
+}
+
+// NotContains asserts that s doesn't contain substr.
+func NotContains(t testing.TB, s, substr string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if strings.Contains(s, substr) {
+		return Fail(t, fmt.Sprintf("%q should not contain %q", s, substr), msgAndArgs...)
+	}
+	return true
+}
+
+// Less asserts that e1 is less than e2.
+func Less[T cmp.Ordered](t testing.TB, e1, e2 T, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !(e1 < e2) {
+		return Fail(t, fmt.Sprintf("%v is not less than %v", e1, e2), msgAndArgs...)
+	}
+	return true
+}
+
+// Returns numeric value of object as float64.
+func toFloat(object interface{}) (float64, bool) {
+	v := reflect.ValueOf(object)
+	switch {
+	case v.CanInt():
+		return float64(v.Int()), true
+	case v.CanUint():
+		return float64(v.Uint()), true
+	case v.CanFloat():
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// InDelta asserts that two numerals are within delta of each other.
+func InDelta(t testing.TB, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	exp, ok1 := toFloat(expected)
+	act, ok2 := toFloat(actual)
+	if !ok1 || !ok2 {
+		return Fail(t, "Parameters must be numerical", msgAndArgs...)
+	}
+	if diff := exp - act; diff < -delta || diff > delta {
+		return Fail(t, fmt.Sprintf("Max difference between %v and %v allowed is %v, but difference was %v", expected, actual, delta, diff), msgAndArgs...)
+	}
+	return true
+}
+
+// WithinDuration asserts that two times are within duration delta
+// of each other.
+func WithinDuration(t testing.TB, expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if diff := expected.Sub(actual); diff < -delta || diff > delta {
+		return Fail(t, fmt.Sprintf("Max difference between %v and %v allowed is %v, but difference was %v", expected, actual, delta, diff), msgAndArgs...)
+	}
+	return true
+}
+
+// JSONEq asserts that two JSON strings are equivalent.
+func JSONEq(t testing.TB, expected, actual string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	var exp, act interface{}
+	if err := json.Unmarshal([]byte(expected), &exp); err != nil {
+		return Fail(t, fmt.Sprintf("Expected value %q is not valid JSON: %v", expected, err), msgAndArgs...)
+	}
+	if err := json.Unmarshal([]byte(actual), &act); err != nil {
+		return Fail(t, fmt.Sprintf("Actual value %q is not valid JSON: %v", actual, err), msgAndArgs...)
+	}
+	if !reflect.DeepEqual(exp, act) {
+		return Fail(t, fmt.Sprintf("Not equal JSON:\nexpected: %s\nactual  : %s", expected, actual), msgAndArgs...)
+	}
+	return true
+}
+
+// NoError asserts that err is nil.
+func NoError(t testing.TB, err error, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if err != nil {
+		return Fail(t, fmt.Sprintf("Received unexpected error:\n%+v", err), msgAndArgs...)
+	}
+	return true
+}
+
+// Error asserts that err isn't nil.
+func Error(t testing.TB, err error, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if err == nil {
+		return Fail(t, "An error is expected but got nil.", msgAndArgs...)
+	}
+	return true
+}
+
+// EqualError asserts that err isn't nil and its message is expected.
+func EqualError(t testing.TB, err error, expected string, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !Error(t, err, msgAndArgs...) {
+		return false
+	}
+	if msg := err.Error(); msg != expected {
+		return Fail(t, fmt.Sprintf("Error message not equal:\nexpected: %q\nactual  : %q", expected, msg), msgAndArgs...)
+	}
+	return true
+}
+
+// ErrorIs asserts that err matches target, as reported by errors.Is.
+func ErrorIs(t testing.TB, err, target error, msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !errors.Is(err, target) {
+		return Fail(t, fmt.Sprintf("Target error should be in err chain:\nexpected: %v\nin chain: %v", target, err), msgAndArgs...)
+	}
+	return true
+}
+
+// Panics asserts that f panics.
+func Panics(t testing.TB, f func(), msgAndArgs ...interface{}) bool {
+	t.Helper()
+	if !didPanic(f) {
+		return Fail(t, "Function should panic", msgAndArgs...)
+	}
+	return true
+}
+
+// Returns true if f panics.
+func didPanic(f func()) (panicked bool) {
+	panicked = true
+	defer func() {
+		_ = recover()
+	}()
+	f()
+	panicked = false
+	return
+}
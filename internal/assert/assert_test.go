@@ -0,0 +1,113 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package assert
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// recorder records failures reported by assertions.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failed = true
+}
+
+func TestAssertions(t *testing.T) {
+	errTest := errors.New("test")
+	var nilSlice []byte
+	x := 42
+
+	tests := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		pass   bool
+	}{
+		{"Equal", func(t testing.TB) bool { return Equal(t, 1, 1) }, true},
+		{"Equal types", func(t testing.TB) bool { return Equal(t, 1, uint8(1)) }, false},
+		{"Equal bytes", func(t testing.TB) bool { return Equal(t, []byte{}, nilSlice) }, true},
+		{"Equal bytes content", func(t testing.TB) bool { return Equal(t, []byte{1}, []byte{2}) }, false},
+		{"NotEqual", func(t testing.TB) bool { return NotEqual(t, 1, 2) }, true},
+		{"NotEqual same", func(t testing.TB) bool { return NotEqual(t, "a", "a") }, false},
+		{"Same", func(t testing.TB) bool { return Same(t, &x, &x) }, true},
+		{"Same copy", func(t testing.TB) bool { y := x; return Same(t, &x, &y) }, false},
+		{"True", func(t testing.TB) bool { return True(t, false) }, false},
+		{"False", func(t testing.TB) bool { return False(t, true) }, false},
+		{"Nil", func(t testing.TB) bool { return Nil(t, nilSlice) }, true},
+		{"Nil value", func(t testing.TB) bool { return Nil(t, 0) }, false},
+		{"Empty", func(t testing.TB) bool { return Empty(t, [2]int{}) }, true},
+		{"Empty slice", func(t testing.TB) bool { return Empty(t, []int{1}) }, false},
+		{"Len", func(t testing.TB) bool { return Len(t, []int{1, 2}, 2) }, true},
+		{"Len mismatch", func(t testing.TB) bool { return Len(t, "abc", 2) }, false},
+		{"Len no length", func(t testing.TB) bool { return Len(t, 1, 1) }, false},
+		{"Contains", func(t testing.TB) bool { return Contains(t, "abc", "b") }, true},
+		{"NotContains", func(t testing.TB) bool { return NotContains(t, "abc", "b") }, false},
+		{"Less", func(t testing.TB) bool { return Less(t, 2, 1) }, false},
+		{"InDelta", func(t testing.TB) bool { return InDelta(t, 10, uint8(11), 1) }, true},
+		{"InDelta far", func(t testing.TB) bool { return InDelta(t, time.Hour, time.Second, 10) }, false},
+		{"InDelta not numbers", func(t testing.TB) bool { return InDelta(t, "1", 1, 1) }, false},
+		{"WithinDuration", func(t testing.TB) bool {
+			return WithinDuration(t, time.Unix(10, 0), time.Unix(0, 0), time.Second)
+		}, false},
+		{"JSONEq", func(t testing.TB) bool { return JSONEq(t, `{"a":1,"b":2}`, `{"b":2, "a":1}`) }, true},
+		{"JSONEq differs", func(t testing.TB) bool { return JSONEq(t, `{"a":1}`, `{"a":2}`) }, false},
+		{"JSONEq invalid", func(t testing.TB) bool { return JSONEq(t, `{`, `{}`) }, false},
+		{"NoError", func(t testing.TB) bool { return NoError(t, errTest) }, false},
+		{"Error", func(t testing.TB) bool { return Error(t, nil) }, false},
+		{"EqualError", func(t testing.TB) bool { return EqualError(t, errTest, "test") }, true},
+		{"EqualError message", func(t testing.TB) bool { return EqualError(t, errTest, "other") }, false},
+		{"ErrorIs", func(t testing.TB) bool { return ErrorIs(t, errTest, errTest) }, true},
+		{"ErrorIs other", func(t testing.TB) bool { return ErrorIs(t, errTest, errors.New("test")) }, false},
+		{"Panics", func(t testing.TB) bool { return Panics(t, func() { panic("test") }) }, true},
+		{"Panics not", func(t testing.TB) bool { return Panics(t, func() {}) }, false},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		if ok := tt.assert(r); ok != tt.pass || r.failed == tt.pass {
+			t.Errorf("%s: expected pass %v, got result %v and failed %v", tt.name, tt.pass, ok, r.failed)
+		}
+	}
+}
+
+func TestMessageFromMsgAndArgs(t *testing.T) {
+	tests := []struct {
+		msgAndArgs []interface{}
+		expected   string
+	}{
+		{nil, ""},
+		{[]interface{}{"message"}, "message"},
+		{[]interface{}{42}, "42"},
+		{[]interface{}{"value %d", 42}, "value 42"},
+	}
+	for _, tt := range tests {
+		if msg := messageFromMsgAndArgs(tt.msgAndArgs...); msg != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, msg)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package require provides test assertions which stop the test on
+// failure. It mirrors the subset of github.com/stretchr/testify API used
+// by tests of this module, which keeps the module free of external
+// dependencies.
+package require
+
+import (
+	"cmp"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+// Equal requires that two objects are equal.
+func Equal(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Equal(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NotEqual requires that two objects aren't equal.
+func NotEqual(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.NotEqual(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Same requires that two pointers reference the same object.
+func Same(t testing.TB, expected, actual interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Same(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// True requires that value is true.
+func True(t testing.TB, value bool, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.True(t, value, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// False requires that value is false.
+func False(t testing.TB, value bool, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.False(t, value, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Nil requires that object is nil.
+func Nil(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Nil(t, object, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Empty requires that object is nil, has zero length or is zero value.
+func Empty(t testing.TB, object interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Empty(t, object, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Len requires that object has given length.
+func Len(t testing.TB, object interface{}, length int, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Len(t, object, length, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Contains requires that s contains substr.
+func Contains(t testing.TB, s, substr string, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Contains(t, s, substr, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NotContains requires that s doesn't contain substr.
+func NotContains(t testing.TB, s, substr string, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.NotContains(t, s, substr, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Less requires that e1 is less than e2.
+func Less[T cmp.Ordered](t testing.TB, e1, e2 T, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Less(t, e1, e2, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// InDelta requires that two numerals are within delta of each other.
+func InDelta(t testing.TB, expected, actual interface{}, delta float64, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.InDelta(t, expected, actual, delta, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// WithinDuration requires that two times are within duration delta
+// of each other.
+func WithinDuration(t testing.TB, expected, actual time.Time, delta time.Duration, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.WithinDuration(t, expected, actual, delta, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// JSONEq requires that two JSON strings are equivalent.
+func JSONEq(t testing.TB, expected, actual string, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.JSONEq(t, expected, actual, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// NoError requires that err is nil.
+func NoError(t testing.TB, err error, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.NoError(t, err, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// Error requires that err isn't nil.
+func Error(t testing.TB, err error, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.Error(t, err, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// EqualError requires that err isn't nil and its message is expected.
+func EqualError(t testing.TB, err error, expected string, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.EqualError(t, err, expected, msgAndArgs...) {
+		t.FailNow()
+	}
+}
+
+// ErrorIs requires that err matches target, as reported by errors.Is.
+func ErrorIs(t testing.TB, err, target error, msgAndArgs ...interface{}) {
+	t.Helper()
+	if !assert.ErrorIs(t, err, target, msgAndArgs...) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package vectors holds test vectors published in RFC 9562 Appendices A
+// and B. It's imported only by tests and by the layout check enabled with
+// uuid_selfcheck build tag, so that other binaries don't carry them.
+package vectors
+
+import (
+	"net"
+	"time"
+)
+
+// Time-based vectors are generated at Tuesday, February 22, 2022
+// 2:22:22.00 PM GMT-05:00.
+var (
+	Time     = time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	Node     = net.HardwareAddr{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+	ClockSeq = []byte{0x33, 0xc8}
+	V7Rand   = []byte{0x0c, 0xc3, 0x18, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}
+
+	V1 = "c232ab00-9414-11ec-b3c8-9f6bdeced846"
+	V3 = "5df41881-3aed-3515-88a7-2f4a814cf09e"
+	V5 = "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+	V6 = "1ec9414c-232a-6b00-b3c8-9f6bdeced846"
+	V7 = "017f22e2-79b0-7cc3-98c4-dc0c0c07398f"
+	V8 = "5c146b14-3c52-8afd-938a-375d0df1fbf6"
+
+	// Name is the name of name-based vectors, in DNS namespace.
+	Name = "www.example.com"
+)
@@ -0,0 +1,204 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+	"testing/quick"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+// Round trip invariants: every encoding of UUID supported by the
+// package must decode back into the very same UUID. They are checked
+// for UUIDs of every version as well as for arbitrary 128 bits, so that
+// forks changing any codec get caught by a single suite.
+
+// roundTrip encodes UUID with one codec and decodes it back.
+type roundTrip struct {
+	name string
+	fn   func(UUID) (UUID, error)
+}
+
+var roundTrips = []roundTrip{
+	{"String/FromString", func(u UUID) (UUID, error) {
+		return FromString(u.String())
+	}},
+	{"String/FromStringCanonical", func(u UUID) (UUID, error) {
+		return FromStringCanonical(u.String())
+	}},
+	{"StringCompact/FromString", func(u UUID) (UUID, error) {
+		return FromString(u.StringCompact())
+	}},
+	{"Formatted/FromAnyString", func(u UUID) (UUID, error) {
+		for _, style := range []FormatStyle{FormatCanonical, FormatBraced, FormatURN, FormatHashLike, FormatUpper} {
+			v, _, err := FromAnyString(u.Formatted(style))
+			if err != nil || v != u {
+				return v, err
+			}
+		}
+		return u, nil
+	}},
+	{"MarshalText/UnmarshalText", func(u UUID) (v UUID, err error) {
+		text, err := u.MarshalText()
+		if err != nil {
+			return Nil, err
+		}
+		err = v.UnmarshalText(text)
+		return
+	}},
+	{"MarshalBinary/UnmarshalBinary", func(u UUID) (v UUID, err error) {
+		data, err := u.MarshalBinary()
+		if err != nil {
+			return Nil, err
+		}
+		err = v.UnmarshalBinary(data)
+		return
+	}},
+	{"JSON", func(u UUID) (v UUID, err error) {
+		data, err := json.Marshal(u)
+		if err != nil {
+			return Nil, err
+		}
+		err = json.Unmarshal(data, &v)
+		return
+	}},
+	{"Value/Scan", func(u UUID) (v UUID, err error) {
+		val, err := u.Value()
+		if err != nil {
+			return Nil, err
+		}
+		err = v.Scan(val)
+		return
+	}},
+	{"NullUUID Value/Scan", func(u UUID) (UUID, error) {
+		var v NullUUID
+		for _, f := range []ValueFormat{TextFormat, CompactFormat, BinaryFormat} {
+			val, err := f.Valuer(NullUUID{UUID: u, Valid: true}).Value()
+			if err != nil {
+				return Nil, err
+			}
+			if err := v.Scan(val); err != nil || v.UUID != u {
+				return v.UUID, err
+			}
+		}
+		return v.UUID, nil
+	}},
+	{"EncodeCanonical/DecodeCanonical", func(u UUID) (v UUID, err error) {
+		var buf [36]byte
+		EncodeCanonical(buf[:], u)
+		err = DecodeCanonical(&v, buf[:])
+		return
+	}},
+	{"EncodeSlice/DecodeSlice", func(u UUID) (UUID, error) {
+		v := make([]UUID, 1)
+		err := DecodeSlice(v, EncodeSlice(nil, []UUID{u}))
+		return v[0], err
+	}},
+	{"Avro", func(u UUID) (v UUID, err error) {
+		data, err := u.MarshalAvro()
+		if err != nil {
+			return Nil, err
+		}
+		err = v.UnmarshalAvro(data)
+		return
+	}},
+	{"MessagePack", func(u UUID) (v UUID, err error) {
+		data, err := u.MarshalMsg(nil)
+		if err != nil {
+			return Nil, err
+		}
+		_, err = v.UnmarshalMsg(data)
+		return
+	}},
+	{"Proquint", func(u UUID) (UUID, error) {
+		return FromProquint(u.Proquint())
+	}},
+	{"FormatDotNet/ParseDotNet", func(u UUID) (UUID, error) {
+		for _, spec := range []byte("NDBPX") {
+			s, err := FormatDotNet(u, spec)
+			if err != nil {
+				return Nil, err
+			}
+			if v, err := ParseDotNet(s); err != nil || v != u {
+				return v, err
+			}
+		}
+		return u, nil
+	}},
+	{"ToOrdered/FromOrdered", func(u UUID) (UUID, error) {
+		return FromOrdered(ToOrdered(u)), nil
+	}},
+	{"ToUint128/FromUint64Pair", func(u UUID) (UUID, error) {
+		return FromUint64Pair(u.ToUint128()), nil
+	}},
+	{"BigInt/FromBigInt", func(u UUID) (UUID, error) {
+		return FromBigInt(u.BigInt())
+	}},
+}
+
+// Returns UUID of every version along with special ones.
+func invariantSamples(t *testing.T) map[string]UUID {
+	samples := map[string]UUID{
+		"Nil": Nil,
+		"Max": Max,
+		"V3":  NewV3(NamespaceDNS, "www.example.com"),
+		"V5":  NewV5(NamespaceDNS, "www.example.com"),
+	}
+	gens := map[string]func() (UUID, error){
+		"V1": NewV1,
+		"V4": NewV4,
+		"V6": NewV6,
+		"V7": NewV7,
+		"V8": func() (UUID, error) { return NewV8WithPrefix([]byte("tenant")) },
+	}
+	for name, gen := range gens {
+		u, err := gen()
+		require.NoError(t, err, name)
+		samples[name] = u
+	}
+	if u, err := NewV2(DomainPerson); err == nil {
+		samples["V2"] = u
+	}
+	return samples
+}
+
+func TestRoundTripInvariants(t *testing.T) {
+	samples := invariantSamples(t)
+	for _, rt := range roundTrips {
+		t.Run(rt.name, func(t *testing.T) {
+			for name, u := range samples {
+				v, err := rt.fn(u)
+				require.NoError(t, err, name)
+				assert.Equal(t, u, v, name)
+			}
+
+			err := quick.Check(func(u UUID) bool {
+				v, err := rt.fn(u)
+				return err == nil && v == u
+			}, nil)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UUIDSlice is a slice of UUIDs encoded in JSON as an array of canonical
+// strings. It is meant for bulk endpoints receiving many IDs at once:
+// it decodes the array in a single pass and reports all invalid elements
+// rather than just the first one.
+type UUIDSlice []UUID
+
+// Max number of invalid indices listed in SliceError message.
+const sliceErrorListed = 10
+
+// SliceError reports all invalid elements of a JSON array decoded
+// into UUIDSlice.
+type SliceError struct {
+	// Indices are the indices of invalid elements, in ascending order.
+	Indices []int
+	// Errors are the errors for elements at corresponding Indices.
+	Errors []error
+}
+
+// Error implements the error interface.
+// Message lists up to 10 invalid indices and the first error.
+func (e *SliceError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "uuid: %d invalid elements at indices ", len(e.Indices))
+	for i, idx := range e.Indices[:min(len(e.Indices), sliceErrorListed)] {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprint(&b, idx)
+	}
+	if len(e.Indices) > sliceErrorListed {
+		fmt.Fprintf(&b, " and %d more", len(e.Indices)-sliceErrorListed)
+	}
+	b.WriteString(", first: ")
+	b.WriteString(e.Errors[0].Error())
+	return b.String()
+}
+
+// Unwrap returns errors for all invalid elements.
+func (e *SliceError) Unwrap() []error {
+	return e.Errors
+}
+
+// Adds error for element at index i.
+func (e *SliceError) add(i int, err error) {
+	e.Indices = append(e.Indices, i)
+	e.Errors = append(e.Errors, fmt.Errorf("uuid: failed to parse UUID at index %d: %w", i, err))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It returns JSON array of canonical strings, or null for nil slice.
+func (s UUIDSlice) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	if len(s) == 0 {
+		return []byte("[]"), nil
+	}
+
+	data := make([]byte, 1+len(s)*39)
+	data[0] = '['
+	for i, u := range s {
+		p := 1 + i*39
+		data[p], data[p+37] = '"', '"'
+		encodeCanonical(data[p+1:p+37], u)
+		data[p+38] = ','
+	}
+	data[len(data)-1] = ']'
+	return data, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It expects JSON array of strings in any form accepted by UnmarshalText.
+// If some elements are invalid, *SliceError listing all of them is
+// returned and s is left intact. Null leaves s intact too.
+func (s *UUIDSlice) UnmarshalJSON(data []byte) error {
+	i := skipJSONSpace(data, 0)
+	if bytes.HasPrefix(data[i:], []byte("null")) && skipJSONSpace(data, i+4) == len(data) {
+		return nil
+	}
+	if i == len(data) || data[i] != '[' {
+		return jsonSyntaxError(data, i)
+	}
+
+	res := make(UUIDSlice, 0, len(data)/39+1)
+	var serr SliceError
+	i = skipJSONSpace(data, i+1)
+	if i < len(data) && data[i] == ']' {
+		i++
+	} else {
+		for idx := 0; ; idx++ {
+			var (
+				u   UUID
+				end int
+				err error
+			)
+			if i < len(data) && data[i] == '"' {
+				end, err = decodeJSONString(&u, data, i)
+				if end < 0 {
+					return jsonSyntaxError(data, len(data))
+				}
+			} else {
+				end = skipJSONValue(data, i)
+				if end < 0 {
+					return jsonSyntaxError(data, len(data))
+				}
+				if end == i {
+					// Empty element, like in "[,]" or "[1,]".
+					return jsonSyntaxError(data, i)
+				}
+				err = fmt.Errorf("uuid: expected string, got %s", errorInput(data[i:end]))
+			}
+			if err != nil {
+				serr.add(idx, err)
+			}
+			res = append(res, u)
+
+			i = skipJSONSpace(data, end)
+			if i < len(data) && data[i] == ',' {
+				i = skipJSONSpace(data, i+1)
+				continue
+			}
+			if i < len(data) && data[i] == ']' {
+				i++
+				break
+			}
+			return jsonSyntaxError(data, i)
+		}
+	}
+	if i = skipJSONSpace(data, i); i != len(data) {
+		return jsonSyntaxError(data, i)
+	}
+
+	if len(serr.Errors) > 0 {
+		return &serr
+	}
+	*s = res
+	return nil
+}
+
+// Decodes JSON string starting at data[start] into u and returns
+// the offset following it, or -1 if the string is unterminated.
+// Strings with escapes, never needed for UUIDs, take the slow path.
+func decodeJSONString(u *UUID, data []byte, start int) (int, error) {
+	escaped := false
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			escaped = true
+			i++
+		case '"':
+			if !escaped {
+				return i + 1, u.UnmarshalText(data[start+1 : i])
+			}
+			var text string
+			if err := json.Unmarshal(data[start:i+1], &text); err != nil {
+				return i + 1, err
+			}
+			return i + 1, u.UnmarshalText([]byte(text))
+		}
+	}
+	return -1, nil
+}
+
+// Returns the offset following JSON value other than string starting
+// at data[start], or -1 if the value is unterminated.
+func skipJSONValue(data []byte, start int) int {
+	depth := 0
+	for i := start; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			for i++; i < len(data) && data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	if depth == 0 {
+		return len(data)
+	}
+	return -1
+}
+
+// Returns the offset of the first non-whitespace byte at or after i.
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// Returns error for malformed JSON array.
+func jsonSyntaxError(data []byte, offset int) error {
+	if offset >= len(data) {
+		return fmt.Errorf("uuid: unexpected end of JSON array")
+	}
+	return fmt.Errorf("uuid: invalid character %q at offset %d of JSON array", data[offset], offset)
+}
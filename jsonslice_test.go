@@ -0,0 +1,178 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestUUIDSliceMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(UUIDSlice{NamespaceDNS, NamespaceURL})
+	require.NoError(t, err)
+	assert.Equal(t, `["6ba7b810-9dad-11d1-80b4-00c04fd430c8","6ba7b811-9dad-11d1-80b4-00c04fd430c8"]`, string(data))
+
+	// Same as encoding/json produces for []UUID.
+	expected, err := json.Marshal([]UUID{NamespaceDNS, NamespaceURL})
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(data))
+
+	data, err = json.Marshal(UUIDSlice{})
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	data, err = json.Marshal(UUIDSlice(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(data))
+}
+
+func TestUUIDSliceUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected UUIDSlice
+	}{
+		{`[]`, UUIDSlice{}},
+		{` [ ] `, UUIDSlice{}},
+		{`["6ba7b810-9dad-11d1-80b4-00c04fd430c8"]`, UUIDSlice{NamespaceDNS}},
+		{"[\n\t\"6ba7b810-9dad-11d1-80b4-00c04fd430c8\" ,\n\t\"{6ba7b811-9dad-11d1-80b4-00c04fd430c8}\"\n]", UUIDSlice{NamespaceDNS, NamespaceURL}},
+		{`["6ba7b810\u002d9dad-11d1-80b4-00c04fd430c8"]`, UUIDSlice{NamespaceDNS}},
+	}
+	for _, tt := range tests {
+		var s UUIDSlice
+		require.NoError(t, json.Unmarshal([]byte(tt.input), &s), tt.input)
+		assert.Equal(t, tt.expected, s, tt.input)
+	}
+
+	s := UUIDSlice{NamespaceDNS}
+	require.NoError(t, json.Unmarshal([]byte("null"), &s))
+	assert.Equal(t, UUIDSlice{NamespaceDNS}, s)
+}
+
+func TestUUIDSliceUnmarshalJSONInvalidElements(t *testing.T) {
+	input := `["6ba7b810-9dad-11d1-80b4-00c04fd430c8", "invalid", null, [1, "]"], {"a": 1}, "6ba7b811-9dad-11d1-80b4-00c04fd430cx"]`
+	s := UUIDSlice{NamespaceOID}
+	err := s.UnmarshalJSON([]byte(input))
+	require.Error(t, err)
+	assert.Equal(t, UUIDSlice{NamespaceOID}, s)
+
+	var serr *SliceError
+	require.True(t, errors.As(err, &serr))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, serr.Indices)
+	assert.Len(t, serr.Errors, 5)
+	assert.Contains(t, err.Error(), "5 invalid elements at indices 1, 2, 3, 4, 5")
+
+	var perr *ParseError
+	require.True(t, errors.As(err, &perr))
+	assert.Equal(t, "invalid", perr.Input)
+
+	// encoding/json passes the error through.
+	err = json.Unmarshal([]byte(input), &s)
+	assert.True(t, errors.As(err, &serr))
+
+	err = s.UnmarshalJSON([]byte(`["invalid"]`))
+	assert.EqualError(t, err, `uuid: failed to parse UUID at index 0: uuid: incorrect UUID length 7 in "invalid", expected 32, 34, 36, 38, 41 or 45 characters`)
+}
+
+func TestUUIDSliceUnmarshalJSONManyInvalid(t *testing.T) {
+	elems := make([]string, 15)
+	for i := range elems {
+		elems[i] = `"x"`
+	}
+	var s UUIDSlice
+	err := s.UnmarshalJSON([]byte("[" + strings.Join(elems, ",") + "]"))
+	assert.Contains(t, err.Error(), "15 invalid elements at indices 0, 1, 2, 3, 4, 5, 6, 7, 8, 9 and 5 more, first: ")
+}
+
+func TestUUIDSliceUnmarshalJSONSyntax(t *testing.T) {
+	for _, input := range []string{
+		``,
+		`{}`,
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`[`,
+		`["6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`["6ba7b810-9dad-11d1-80b4-00c04fd430c8",]`,
+		`["6ba7b810-9dad-11d1-80b4-00c04fd430c8" "6ba7b811-9dad-11d1-80b4-00c04fd430c8"]`,
+		`["6ba7b810-9dad-11d1-80b4-00c04fd430c8] `,
+		`[] x`,
+		`[[1]`,
+		`[{`,
+		`[[1,2`,
+		`[{"a": "]"`,
+		`[,]`,
+		`[1,]`,
+		`[1,,2]`,
+		`[,"6ba7b810-9dad-11d1-80b4-00c04fd430c8"]`,
+	} {
+		var s UUIDSlice
+		assert.Error(t, s.UnmarshalJSON([]byte(input)), input)
+	}
+}
+
+func benchmarkSliceJSON(n int) []byte {
+	ids := make([]UUID, n)
+	for i := range ids {
+		ids[i] = Must(NewV4())
+	}
+	data, _ := json.Marshal(ids)
+	return data
+}
+
+func BenchmarkUUIDSliceUnmarshalJSON(b *testing.B) {
+	data := benchmarkSliceJSON(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s UUIDSlice
+		if err := json.Unmarshal(data, &s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUUIDSliceUnmarshalJSONDirect(b *testing.B) {
+	data := benchmarkSliceJSON(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s UUIDSlice
+		if err := s.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlainSliceUnmarshalJSON(b *testing.B) {
+	data := benchmarkSliceJSON(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var s []UUID
+		if err := json.Unmarshal(data, &s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
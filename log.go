@@ -0,0 +1,70 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/hex"
+	"log/slog"
+)
+
+// LogValue implements the slog.LogValuer interface.
+// UUID is logged in its canonical string representation.
+func (u UUID) LogValue() slog.Value {
+	return slog.StringValue(u.String())
+}
+
+// LogValue implements the slog.LogValuer interface.
+// Valid UUID is logged in its canonical string representation,
+// otherwise the value is logged as nil.
+func (u NullUUID) LogValue() slog.Value {
+	if !u.Valid {
+		return slog.AnyValue(nil)
+	}
+	return u.UUID.LogValue()
+}
+
+// Redacted returns string representation of UUID with all but the first
+// eight and the last four hexadecimal digits masked:
+// xxxxxxxx-****-****-****-********xxxx.
+// Masked UUIDs are still correlatable in logs without being disclosed.
+func (u UUID) Redacted() string {
+	buf := []byte("00000000-****-****-****-********0000")
+	hex.Encode(buf[0:8], u[0:4])
+	hex.Encode(buf[32:], u[14:])
+	return string(buf)
+}
+
+// RedactedUUID is a UUID which is formatted in redacted form
+// both by fmt and log/slog packages.
+//
+//	logger.Info("session started", "session", uuid.RedactedUUID(u))
+type RedactedUUID UUID
+
+// String returns redacted string representation of UUID.
+func (u RedactedUUID) String() string {
+	return UUID(u).Redacted()
+}
+
+// LogValue implements the slog.LogValuer interface.
+func (u RedactedUUID) LogValue() slog.Value {
+	return slog.StringValue(u.String())
+}
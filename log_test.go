@@ -0,0 +1,63 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+func TestLogValue(t *testing.T) {
+	assert.Equal(t, slog.StringValue("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), NamespaceDNS.LogValue())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("test", "id", NamespaceDNS)
+	assert.Contains(t, buf.String(), `"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`)
+}
+
+func TestNullUUIDLogValue(t *testing.T) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	assert.Equal(t, slog.StringValue("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), u.LogValue())
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("test", "id", NullUUID{})
+	assert.Contains(t, buf.String(), `"id":null`)
+}
+
+func TestRedacted(t *testing.T) {
+	assert.Equal(t, "6ba7b810-****-****-****-********30c8", NamespaceDNS.Redacted())
+	assert.Equal(t, "6ba7b810-****-****-****-********30c8", RedactedUUID(NamespaceDNS).String())
+	assert.Equal(t, "6ba7b810-****-****-****-********30c8", fmt.Sprint(RedactedUUID(NamespaceDNS)))
+}
+
+func TestRedactedUUIDLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("test", "id", RedactedUUID(NamespaceDNS))
+	assert.Contains(t, buf.String(), "id=6ba7b810-****-****-****-********30c8")
+}
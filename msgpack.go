@@ -0,0 +1,88 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import "fmt"
+
+// MessagePack format markers.
+const (
+	msgpackBin8 = 0xc4
+	msgpackStr8 = 0xd9
+)
+
+// Size of UUID encoded as MessagePack bin 8 value.
+const msgpackSize = 2 + Size
+
+// MarshalMsg appends UUID encoded as 16 bytes MessagePack bin value to b.
+// It implements the msgp.Marshaler interface of github.com/tinylib/msgp,
+// making UUID usable in code generated by msgp. Libraries encoding values
+// implementing encoding.BinaryMarshaler as bin, such as
+// github.com/vmihailenco/msgpack, produce the same encoding.
+func (u UUID) MarshalMsg(b []byte) ([]byte, error) {
+	b = append(b, msgpackBin8, Size)
+	return append(b, u[:]...), nil
+}
+
+// UnmarshalMsg decodes UUID from MessagePack value at the start of b and
+// returns the remaining bytes. It implements the msgp.Unmarshaler
+// interface. Besides bin values produced by MarshalMsg, str values
+// holding UUID in a form accepted by UnmarshalText are supported.
+// On error b is returned unconsumed.
+func (u *UUID) UnmarshalMsg(b []byte) ([]byte, error) {
+	if len(b) < 2 {
+		return b, fmt.Errorf("uuid: MessagePack value too short: %d bytes", len(b))
+	}
+
+	var n int
+	data := b
+	binary := b[0] == msgpackBin8
+	switch {
+	case binary || b[0] == msgpackStr8:
+		n = int(b[1])
+		data = b[2:]
+	case b[0]&0xe0 == 0xa0: // fixstr
+		n = int(b[0] & 0x1f)
+		data = b[1:]
+	default:
+		return b, fmt.Errorf("uuid: unsupported MessagePack type 0x%02x", b[0])
+	}
+
+	if len(data) < n {
+		return b, fmt.Errorf("uuid: MessagePack value too short: %d bytes, expected %d", len(data), n)
+	}
+	var err error
+	if binary {
+		err = u.UnmarshalBinary(data[:n])
+	} else {
+		err = u.UnmarshalText(data[:n])
+	}
+	if err != nil {
+		return b, err
+	}
+	return data[n:], nil
+}
+
+// Msgsize returns upper bound of size of UUID encoded by MarshalMsg.
+// It implements the msgp.Sizer interface.
+func (u UUID) Msgsize() int {
+	return msgpackSize
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestMarshalMsg(t *testing.T) {
+	b, err := NamespaceDNS.MarshalMsg([]byte{0x92})
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0x92, 0xc4, 0x10}, NamespaceDNS[:]...), b)
+	assert.Equal(t, len(b)-1, NamespaceDNS.Msgsize())
+}
+
+func TestUnmarshalMsg(t *testing.T) {
+	b, err := NamespaceDNS.MarshalMsg(nil)
+	require.NoError(t, err)
+	b = append(b, 0xc0)
+
+	var u UUID
+	rest, err := u.UnmarshalMsg(b)
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u)
+	assert.Equal(t, []byte{0xc0}, rest)
+}
+
+func TestUnmarshalMsgString(t *testing.T) {
+	s := NamespaceURL.String()
+	b := append([]byte{0xd9, byte(len(s))}, s...)
+
+	var u UUID
+	rest, err := u.UnmarshalMsg(b)
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceURL, u)
+	assert.Empty(t, rest)
+
+	var u1 UUID
+	_, err = u1.UnmarshalMsg([]byte{0xa5, 'h', 'e', 'l', 'l', 'o'})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalMsgInvalid(t *testing.T) {
+	tests := [][]byte{
+		nil,
+		{0xc4},
+		{0xc4, 0x10, 0x01},
+		{0xc5, 0x00, 0x10},
+		{0xc4, 0x02, 0x01, 0x02},
+		// 16-character str is text, not binary UUID.
+		append([]byte{0xb0}, "0123456789abcdef"...),
+		append([]byte{0xd9, 0x10}, "0123456789abcdef"...),
+	}
+	for _, b := range tests {
+		var u UUID
+		rest, err := u.UnmarshalMsg(b)
+		assert.Error(t, err, b)
+		assert.Equal(t, b, rest)
+	}
+}
+
+func BenchmarkMarshalMsg(b *testing.B) {
+	u := MustNewV4()
+	buf := make([]byte, 0, u.Msgsize())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = u.MarshalMsg(buf[:0])
+	}
+}
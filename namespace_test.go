@@ -0,0 +1,84 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+func TestNamespaceGenerator(t *testing.T) {
+	g := NewNamespaceGenerator(NamespaceDNS)
+	assert.Equal(t, NamespaceDNS, g.Namespace())
+
+	for _, name := range []string{"", "www.example.com", string(make([]byte, 1000))} {
+		assert.Equal(t, NewV3(NamespaceDNS, name), g.V3(name))
+		assert.Equal(t, NewV5(NamespaceDNS, name), g.V5(name))
+		assert.Equal(t, NewV3(NamespaceDNS, name), g.V3Bytes([]byte(name)))
+		assert.Equal(t, NewV5(NamespaceDNS, name), g.V5Bytes([]byte(name)))
+	}
+	assert.Equal(t, vectors.V5, g.V5(vectors.Name).String())
+	assert.Equal(t, vectors.V3, g.V3(vectors.Name).String())
+}
+
+func TestGeneratorNamespace(t *testing.T) {
+	gen := NewGenerator()
+	g := gen.(NamespacedGenerator).Namespace(NamespaceURL)
+	assert.Equal(t, NamespaceURL, g.Namespace())
+	assert.Equal(t, gen.NewV5(NamespaceURL, "a"), g.V5("a"))
+	assert.Equal(t, gen.NewV3(NamespaceURL, "a"), g.V3("a"))
+}
+
+func TestNamespaceGeneratorConcurrent(t *testing.T) {
+	g := NewNamespaceGenerator(NamespaceURL)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				name := string(rune('a' + j%26))
+				assert.Equal(t, NewV5(NamespaceURL, name), g.V5(name))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkNamespaceGeneratorV5(b *testing.B) {
+	g := NewNamespaceGenerator(NamespaceDNS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = g.V5("www.example.com")
+	}
+}
+
+func BenchmarkNamespaceGeneratorV3(b *testing.B) {
+	g := NewNamespaceGenerator(NamespaceDNS)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = g.V3("www.example.com")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+)
+
+// NodeSelection determines which network interface provides
+// the node ID of time-based UUIDs.
+type NodeSelection int
+
+const (
+	// NodeSelectionFirst picks the first interface with a hardware
+	// address, in the order reported by the system. It is the default.
+	NodeSelectionFirst NodeSelection = iota
+	// NodeSelectionPhysical prefers interfaces which are up, aren't
+	// loopback and have a globally administered hardware address,
+	// skipping virtual bridges with locally administered addresses.
+	// It falls back to NodeSelectionFirst if no such interface exists.
+	NodeSelectionPhysical
+	// NodeSelectionSortedMAC picks the interface with the lowest
+	// hardware address, independent of the interfaces order.
+	NodeSelectionSortedMAC
+)
+
+// WithNodeSelection sets strategy of picking the network interface
+// which provides the node ID of time-based UUIDs.
+func WithNodeSelection(selection NodeSelection) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.nodeSelect = selection
+	}
+}
+
+// WithNodeInterface makes generator take the node ID of time-based UUIDs
+// from the network interface with given name, e.g. "eth0", if it exists
+// and has a hardware address. Otherwise the node selection strategy
+// applies.
+func WithNodeInterface(name string) GeneratorOption {
+	return func(g *rfc4122Generator) {
+		g.nodeIface = name
+	}
+}
+
+// Returns hardware address.
+func defaultHWAddrFunc() (net.HardwareAddr, error) {
+	return interfaceHWAddr(NodeSelectionFirst, "")
+}
+
+// Returns hardware address picked according to generator configuration.
+func (g *rfc4122Generator) interfaceHWAddr() (net.HardwareAddr, error) {
+	return interfaceHWAddr(g.nodeSelect, g.nodeIface)
+}
+
+// Returns hardware address of one of ifaces picked according to
+// selection and preferred interface name.
+func selectHWAddr(ifaces []net.Interface, selection NodeSelection, name string) (net.HardwareAddr, error) {
+	var candidates []net.Interface
+	for _, iface := range ifaces {
+		if len(iface.HardwareAddr) < 6 {
+			continue
+		}
+		if name != "" && iface.Name == name {
+			return iface.HardwareAddr, nil
+		}
+		candidates = append(candidates, iface)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("uuid: no HW address found")
+	}
+
+	switch selection {
+	case NodeSelectionPhysical:
+		for _, iface := range candidates {
+			if isPhysical(iface) {
+				return iface.HardwareAddr, nil
+			}
+		}
+	case NodeSelectionSortedMAC:
+		lowest := candidates[0].HardwareAddr
+		for _, iface := range candidates[1:] {
+			if bytes.Compare(iface.HardwareAddr, lowest) < 0 {
+				lowest = iface.HardwareAddr
+			}
+		}
+		return lowest, nil
+	}
+	return candidates[0].HardwareAddr, nil
+}
+
+// Reports whether iface looks like a physical network interface:
+// it is up, isn't loopback and its hardware address is globally
+// administered unicast one.
+func isPhysical(iface net.Interface) bool {
+	return iface.Flags&net.FlagUp != 0 &&
+		iface.Flags&net.FlagLoopback == 0 &&
+		iface.HardwareAddr[0]&0x03 == 0
+}
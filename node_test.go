@@ -0,0 +1,96 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"net"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func testInterfaces() []net.Interface {
+	mac := func(s string) net.HardwareAddr {
+		hwAddr, _ := net.ParseMAC(s)
+		return hwAddr
+	}
+	return []net.Interface{
+		{Name: "lo", Flags: net.FlagUp | net.FlagLoopback},
+		{Name: "docker0", Flags: net.FlagUp, HardwareAddr: mac("02:42:ac:11:00:02")},
+		{Name: "eth1", HardwareAddr: mac("00:1b:21:3a:4f:10")},
+		{Name: "eth0", Flags: net.FlagUp, HardwareAddr: mac("00:1b:21:3a:4f:11")},
+		{Name: "eth2", Flags: net.FlagUp, HardwareAddr: mac("00:0c:29:01:02:03")},
+	}
+}
+
+func TestSelectHWAddr(t *testing.T) {
+	ifaces := testInterfaces()
+	tests := []struct {
+		selection NodeSelection
+		name      string
+		expected  string
+	}{
+		{NodeSelectionFirst, "", "02:42:ac:11:00:02"},
+		{NodeSelectionPhysical, "", "00:1b:21:3a:4f:11"},
+		{NodeSelectionSortedMAC, "", "00:0c:29:01:02:03"},
+		{NodeSelectionFirst, "eth1", "00:1b:21:3a:4f:10"},
+		{NodeSelectionSortedMAC, "eth0", "00:1b:21:3a:4f:11"},
+		{NodeSelectionPhysical, "wlan0", "00:1b:21:3a:4f:11"},
+		{NodeSelectionFirst, "lo", "02:42:ac:11:00:02"},
+	}
+	for _, tt := range tests {
+		hwAddr, err := selectHWAddr(ifaces, tt.selection, tt.name)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, hwAddr.String(), "selection %d, name %q", tt.selection, tt.name)
+	}
+}
+
+func TestSelectHWAddrFallback(t *testing.T) {
+	ifaces := testInterfaces()[:2]
+	hwAddr, err := selectHWAddr(ifaces, NodeSelectionPhysical, "")
+	require.NoError(t, err)
+	assert.Equal(t, "02:42:ac:11:00:02", hwAddr.String())
+
+	_, err = selectHWAddr(ifaces[:1], NodeSelectionFirst, "")
+	assert.Error(t, err)
+}
+
+func TestWithNodeSelection(t *testing.T) {
+	g := newRFC4122Generator()
+	WithNodeSelection(NodeSelectionSortedMAC)(g)
+	WithNodeInterface("eth0")(g)
+	assert.Equal(t, NodeSelectionSortedMAC, g.nodeSelect)
+	assert.Equal(t, "eth0", g.nodeIface)
+
+	// Options apply regardless of their order relative to
+	// WithHashedNodeID.
+	g1 := NewGenerator(WithHashedNodeID([]byte("secret")), WithNodeSelection(NodeSelectionSortedMAC))
+	g2 := NewGenerator(WithNodeSelection(NodeSelectionSortedMAC), WithHashedNodeID([]byte("secret")))
+	u1, err := g1.NewV1()
+	require.NoError(t, err)
+	u2, err := g2.NewV1()
+	require.NoError(t, err)
+	if _, err := interfaceHWAddr(NodeSelectionSortedMAC, ""); err == nil {
+		assert.Equal(t, u1[10:], u2[10:])
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sync/atomic"
+)
+
+// NormalizeEvent describes text scanned into NormalizingUUID which isn't
+// in canonical lowercase form, e.g. uppercase or padded with spaces.
+type NormalizeEvent struct {
+	// Input is the original text.
+	Input string
+	// UUID is the value decoded from Input.
+	UUID UUID
+}
+
+// Observer of normalizations, see SetNormalizeObserver.
+var normalizeObserver atomic.Pointer[func(NormalizeEvent)]
+
+// SetNormalizeObserver makes NormalizingUUID call observer on every
+// NormalizeEvent, e.g. to log or count sources of dirty data, or stops
+// notifying if observer is nil. The observer must be safe for concurrent
+// use, as Scan may be called from many goroutines.
+func SetNormalizeObserver(observer func(NormalizeEvent)) {
+	if observer == nil {
+		normalizeObserver.Store(nil)
+		return
+	}
+	normalizeObserver.Store(&observer)
+}
+
+// Characters trimmed from text scanned into NormalizingUUID, including
+// padding of CHAR columns.
+const normalizeCutset = " \t\r\n\x00"
+
+// NormalizingUUID is a UUID scanned leniently from dirty data, such as
+// uppercase GUID strings padded with trailing spaces by CHAR columns.
+// Surrounding whitespace and NUL characters are trimmed and hex digits
+// are accepted in any case before the value is validated. It's written
+// back in canonical lowercase form.
+type NormalizingUUID UUID
+
+// Value implements the driver.Valuer interface.
+// It returns the canonical string representation of UUID.
+func (u NormalizingUUID) Value() (driver.Value, error) {
+	return UUID(u).String(), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same
+// inputs as UUID.Scan, with text trimmed of surrounding whitespace.
+// If text isn't in canonical lowercase form, the observer set with
+// SetNormalizeObserver is notified.
+func (u *NormalizingUUID) Scan(src interface{}) error {
+	var text []byte
+	switch src := src.(type) {
+	case string:
+		text = []byte(src)
+	case []byte:
+		if len(src) == Size {
+			return (*UUID)(u).Scan(src)
+		}
+		text = src
+	default:
+		return (*UUID)(u).Scan(src)
+	}
+	return u.UnmarshalText(text)
+}
+
+// String returns canonical string representation of UUID.
+func (u NormalizingUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It returns the canonical string representation of UUID.
+func (u NormalizingUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText, trimmed of surrounding
+// whitespace as by Scan, and notifies the observer the same way.
+func (u *NormalizingUUID) UnmarshalText(text []byte) error {
+	var v UUID
+	if err := v.UnmarshalText(bytes.Trim(text, normalizeCutset)); err != nil {
+		return err
+	}
+	if observer := normalizeObserver.Load(); observer != nil && !isCanonical(text, v) {
+		(*observer)(NormalizeEvent{Input: string(text), UUID: v})
+	}
+	*u = NormalizingUUID(v)
+	return nil
+}
+
+// Returns true if text is canonical representation of u.
+func isCanonical(text []byte, u UUID) bool {
+	var buf [36]byte
+	encodeCanonical(buf[:], u)
+	return bytes.Equal(text, buf[:])
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestNormalizingUUID(t *testing.T) {
+	var mu sync.Mutex
+	var observed []string
+	SetNormalizeObserver(func(e NormalizeEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, NamespaceDNS, e.UUID)
+		observed = append(observed, e.Input)
+	})
+	t.Cleanup(func() { SetNormalizeObserver(nil) })
+
+	inputs := []interface{}{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		NamespaceDNS.Bytes(),
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8    ",
+		[]byte(" {6ba7b810-9dad-11d1-80b4-00c04fd430c8}\x00"),
+		"\t6ba7b8109dad11d180b400c04fd430c8\n",
+	}
+	for _, input := range inputs {
+		var u NormalizingUUID
+		require.NoError(t, u.Scan(input), input)
+		assert.Equal(t, NormalizingUUID(NamespaceDNS), u)
+	}
+	assert.Equal(t, []string{
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8    ",
+		" {6ba7b810-9dad-11d1-80b4-00c04fd430c8}\x00",
+		"\t6ba7b8109dad11d180b400c04fd430c8\n",
+	}, observed)
+
+	var u NormalizingUUID
+	assert.Error(t, u.Scan("6ba7b810-9dad-11d1-80b4-00c04fd430c8x"))
+	assert.Error(t, u.Scan(42))
+	assert.Equal(t, NormalizingUUID(Nil), u)
+
+	val, err := NormalizingUUID(NamespaceDNS).Value()
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS.String(), val)
+	assert.Equal(t, NamespaceDNS.String(), NormalizingUUID(NamespaceDNS).String())
+}
+
+func TestNormalizingUUIDWithoutObserver(t *testing.T) {
+	var u NormalizingUUID
+	require.NoError(t, u.Scan("6BA7B810-9DAD-11D1-80B4-00C04FD430C8  "))
+	assert.Equal(t, NormalizingUUID(NamespaceDNS), u)
+}
+
+func TestNormalizingUUIDJSON(t *testing.T) {
+	data, err := json.Marshal(NormalizingUUID(NamespaceDNS))
+	require.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(data))
+
+	var u NormalizingUUID
+	require.NoError(t, json.Unmarshal([]byte(`" 6BA7B810-9DAD-11D1-80B4-00C04FD430C8\t"`), &u))
+	assert.Equal(t, NormalizingUUID(NamespaceDNS), u)
+	assert.Error(t, json.Unmarshal([]byte(`"invalid"`), &u))
+}
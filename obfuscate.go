@@ -0,0 +1,105 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+// Number of Feistel rounds used by Obfuscator.
+const obfuscatorRounds = 4
+
+// Mask of the 61 bits halves permuted by Obfuscator.
+const obfuscatorHalfMask = 1<<61 - 1
+
+// Obfuscator maps UUIDs to random looking ones and back with a secret
+// key, so that version 7 UUIDs can be exposed publicly without leaking
+// their creation time, ordering and generation rate, while remaining
+// mappable back internally.
+//
+// It's a 4 rounds Feistel network with AES as round function, permuting
+// the 122 bits of UUID other than version and variant. Version and
+// variant are kept, so obfuscated UUIDs are still valid UUIDs of the same
+// version. The mapping is deterministic: the same UUID is always mapped
+// to the same value under the same key. It hides information, but isn't
+// meant as authenticated encryption; any UUID deobfuscates to some UUID.
+type Obfuscator struct {
+	block cipher.Block
+}
+
+// NewObfuscator returns Obfuscator using key, which must be 16, 24
+// or 32 bytes long.
+func NewObfuscator(key []byte) (*Obfuscator, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("uuid: invalid obfuscation key: %w", err)
+	}
+	return &Obfuscator{block: block}, nil
+}
+
+// Obfuscate returns UUID with all bits except version and variant
+// permuted under the key.
+func (o *Obfuscator) Obfuscate(u UUID) UUID {
+	l, r := splitPayload(u)
+	for i := 0; i < obfuscatorRounds; i++ {
+		l, r = r, l^o.round(i, r)
+	}
+	return joinPayload(u, l, r)
+}
+
+// Deobfuscate returns UUID obfuscated by Obfuscate with the same key.
+func (o *Obfuscator) Deobfuscate(u UUID) UUID {
+	l, r := splitPayload(u)
+	for i := obfuscatorRounds - 1; i >= 0; i-- {
+		l, r = r^o.round(i, l), l
+	}
+	return joinPayload(u, l, r)
+}
+
+// Returns round function of half x, 61 bits long.
+func (o *Obfuscator) round(i int, x uint64) uint64 {
+	var buf [aes.BlockSize]byte
+	buf[0] = byte(i)
+	binary.BigEndian.PutUint64(buf[8:], x)
+	o.block.Encrypt(buf[:], buf[:])
+	return binary.BigEndian.Uint64(buf[:]) & obfuscatorHalfMask
+}
+
+// Splits 122 bits of UUID other than version and variant into
+// two 61 bits halves.
+func splitPayload(u UUID) (l, r uint64) {
+	hi, lo := u.ToUint128()
+	hi = hi>>16<<12 | hi&0x0fff // 60 bits without version
+	lo &= 1<<62 - 1             // 62 bits without variant
+	return hi<<1 | lo>>61, lo & obfuscatorHalfMask
+}
+
+// Joins two 61 bits halves into UUID with version and variant of u.
+func joinPayload(u UUID, l, r uint64) UUID {
+	hi, lo := u.ToUint128()
+	hi = hi&0xf000 | l>>13<<16 | l>>1&0x0fff
+	lo = lo&^(1<<62-1) | (l&1)<<61 | r
+	return FromUint64Pair(hi, lo)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"sort"
+	"testing"
+	"testing/quick"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+var obfuscatorKey = []byte("0123456789abcdef")
+
+func TestObfuscator(t *testing.T) {
+	o, err := NewObfuscator(obfuscatorKey)
+	require.NoError(t, err)
+
+	u := Must(NewV7())
+	x := o.Obfuscate(u)
+	assert.NotEqual(t, u, x)
+	assert.Equal(t, V7, x.Version())
+	assert.Equal(t, VariantRFC4122, x.Variant())
+	assert.Equal(t, x, o.Obfuscate(u))
+	assert.Equal(t, u, o.Deobfuscate(x))
+
+	o2, err := NewObfuscator([]byte("fedcba9876543210"))
+	require.NoError(t, err)
+	assert.NotEqual(t, x, o2.Obfuscate(u))
+
+	_, err = NewObfuscator([]byte("short"))
+	assert.Error(t, err)
+}
+
+func TestObfuscatorRoundTrip(t *testing.T) {
+	o, err := NewObfuscator(obfuscatorKey)
+	require.NoError(t, err)
+
+	// Any 128 bits round trip, including version and variant bits.
+	assert.NoError(t, quick.Check(func(u UUID) bool {
+		x := o.Obfuscate(u)
+		return o.Deobfuscate(x) == u && x[6]>>4 == u[6]>>4 && x[8]>>6 == u[8]>>6
+	}, nil))
+}
+
+func TestSplitPayload(t *testing.T) {
+	assert.NoError(t, quick.Check(func(u UUID) bool {
+		l, r := splitPayload(u)
+		return l <= obfuscatorHalfMask && r <= obfuscatorHalfMask && joinPayload(u, l, r) == u
+	}, nil))
+
+	// Version and variant bits aren't part of payload.
+	l, r := splitPayload(Must(FromString("00000000-0000-f000-c000-000000000000")))
+	assert.Equal(t, uint64(0), l)
+	assert.Equal(t, uint64(0), r)
+}
+
+func TestObfuscatorHidesOrder(t *testing.T) {
+	o, err := NewObfuscator(obfuscatorKey)
+	require.NoError(t, err)
+
+	g := NewGenerator(WithMonotonicV7())
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i] = o.Obfuscate(Must(g.NewV7())).String()
+	}
+	assert.False(t, sort.StringsAreSorted(ids))
+}
+
+func BenchmarkObfuscate(b *testing.B) {
+	o, err := NewObfuscator(obfuscatorKey)
+	require.NoError(b, err)
+	u := Must(NewV7())
+	for i := 0; i < b.N; i++ {
+		_ = o.Obfuscate(u)
+	}
+}
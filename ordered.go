@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// ToOrdered returns UUID with time fields of version 1 UUID rearranged
+// so that time_high goes first, followed by time_mid and time_low.
+// This is the layout produced by MySQL UUID_TO_BIN(uuid, 1) and keeps
+// consecutively generated UUIDs close to each other in B-tree indexes.
+func ToOrdered(u UUID) UUID {
+	o := UUID{}
+	copy(o[0:2], u[6:8])
+	copy(o[2:4], u[4:6])
+	copy(o[4:8], u[0:4])
+	copy(o[8:], u[8:])
+	return o
+}
+
+// FromOrdered returns UUID restored from the layout produced by ToOrdered.
+func FromOrdered(o UUID) UUID {
+	u := UUID{}
+	copy(u[0:4], o[4:8])
+	copy(u[4:6], o[2:4])
+	copy(u[6:8], o[0:2])
+	copy(u[8:], o[8:])
+	return u
+}
+
+// OrderedUUID is a UUID stored in the database in the layout
+// produced by ToOrdered, typically in a BINARY(16) column.
+type OrderedUUID UUID
+
+// Value implements the driver.Valuer interface.
+// It returns 16 bytes of UUID in ordered layout.
+func (u OrderedUUID) Value() (driver.Value, error) {
+	o := ToOrdered(UUID(u))
+	return o[:], nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It expects 16 bytes of UUID in ordered layout.
+func (u *OrderedUUID) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("uuid: cannot convert %T to OrderedUUID", src)
+	}
+	o, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+	*u = OrderedUUID(FromOrdered(o))
+	return nil
+}
+
+// String returns canonical string representation of UUID
+// in its standard layout.
+func (u OrderedUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It returns canonical string representation of UUID in its standard
+// layout, as String does.
+func (u OrderedUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText, in standard layout.
+func (u *OrderedUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestToOrdered(t *testing.T) {
+	u := MustFromString("58e0a7d7-eebc-11d8-9669-0800200c9a66")
+	o := ToOrdered(u)
+	assert.Equal(t, MustFromString("11d8eebc-58e0-a7d7-9669-0800200c9a66"), o)
+	assert.Equal(t, u, FromOrdered(o))
+}
+
+func TestOrderedUUIDValue(t *testing.T) {
+	u := MustFromString("58e0a7d7-eebc-11d8-9669-0800200c9a66")
+
+	val, err := OrderedUUID(u).Value()
+	require.NoError(t, err)
+	assert.Equal(t, ToOrdered(u).Bytes(), val)
+	assert.Equal(t, u.String(), OrderedUUID(u).String())
+}
+
+func TestOrderedUUIDScan(t *testing.T) {
+	u := MustFromString("58e0a7d7-eebc-11d8-9669-0800200c9a66")
+	o := ToOrdered(u)
+
+	var u1 OrderedUUID
+	err := u1.Scan(o[:])
+	require.NoError(t, err)
+	assert.Equal(t, u, UUID(u1))
+
+	err = u1.Scan([]byte{0x01})
+	assert.Error(t, err)
+
+	err = u1.Scan(u.String())
+	assert.Error(t, err)
+}
+
+func TestOrderedUUIDJSON(t *testing.T) {
+	u := OrderedUUID(MustFromString("58e0a7d7-eebc-11d8-9669-0800200c9a66"))
+	data, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Equal(t, `"58e0a7d7-eebc-11d8-9669-0800200c9a66"`, string(data))
+
+	var got OrderedUUID
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, u, got)
+	assert.Error(t, json.Unmarshal([]byte(`"invalid"`), &got))
+}
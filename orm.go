@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import "database/sql/driver"
+
+// V7Key is a UUID primary key for models mapped by ORMs such as GORM
+// or ent, meant to be populated with version 7 UUID on insert.
+// It's stored in the database as UUID and encoded as text.
+//
+// ORMs without database-side generation can populate it from a hook:
+//
+//	type User struct {
+//		ID   uuid.V7Key `gorm:"primaryKey"`
+//		Name string
+//	}
+//
+//	func (u *User) BeforeCreate(*gorm.DB) error {
+//		return u.ID.EnsureV7()
+//	}
+type V7Key UUID
+
+// NewV7Key returns V7Key holding a new version 7 UUID.
+func NewV7Key() (V7Key, error) {
+	u, err := NewV7()
+	return V7Key(u), err
+}
+
+// EnsureV7 populates key with a new version 7 UUID if it's Nil,
+// leaving keys set by the caller intact.
+func (k *V7Key) EnsureV7() error {
+	if UUID(*k) != Nil {
+		return nil
+	}
+	u, err := NewV7()
+	if err != nil {
+		return err
+	}
+	*k = V7Key(u)
+	return nil
+}
+
+// UUID returns key as UUID.
+func (k V7Key) UUID() UUID {
+	return UUID(k)
+}
+
+// String returns canonical string representation of key.
+func (k V7Key) String() string {
+	return UUID(k).String()
+}
+
+// GormDataType returns data type GORM uses for the key column.
+func (k V7Key) GormDataType() string {
+	return "uuid"
+}
+
+// Value implements the driver.Valuer interface.
+func (k V7Key) Value() (driver.Value, error) {
+	return UUID(k).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (k *V7Key) Scan(src interface{}) error {
+	return (*UUID)(k).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (k V7Key) MarshalText() ([]byte, error) {
+	return UUID(k).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (k *V7Key) UnmarshalText(text []byte) error {
+	return (*UUID)(k).UnmarshalText(text)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestNewV7Key(t *testing.T) {
+	k, err := NewV7Key()
+	require.NoError(t, err)
+	assert.Equal(t, V7, k.UUID().Version())
+	assert.Equal(t, k.UUID().String(), k.String())
+	assert.Equal(t, "uuid", k.GormDataType())
+}
+
+func TestV7KeyEnsureV7(t *testing.T) {
+	var k V7Key
+	require.NoError(t, k.EnsureV7())
+	assert.Equal(t, V7, k.UUID().Version())
+
+	k1 := k
+	require.NoError(t, k1.EnsureV7())
+	assert.Equal(t, k, k1)
+}
+
+func TestV7KeySQL(t *testing.T) {
+	k := V7Key(NamespaceDNS)
+
+	val, err := k.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", val)
+
+	var k1 V7Key
+	require.NoError(t, k1.Scan(val))
+	assert.Equal(t, k, k1)
+
+	assert.Error(t, k1.Scan(42))
+}
+
+func TestV7KeyJSON(t *testing.T) {
+	k := V7Key(NamespaceDNS)
+
+	data, err := json.Marshal(k)
+	require.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(data))
+
+	var k1 V7Key
+	require.NoError(t, json.Unmarshal(data, &k1))
+	assert.Equal(t, k, k1)
+}
@@ -0,0 +1,10 @@
+module github.com/satori/go.uuid/pb
+
+go 1.23.0
+
+require (
+	github.com/satori/go.uuid v0.0.0
+	google.golang.org/protobuf v1.36.11
+)
+
+replace github.com/satori/go.uuid => ../
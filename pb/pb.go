@@ -0,0 +1,106 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package pb provides helpers for carrying UUIDs in Protocol Buffers
+// messages, either in a plain bytes field or in the UUID message
+// described by uuid.proto, whose generated code is in this package.
+//
+// UUIDs are encoded as 16 raw bytes. An empty bytes field decodes to
+// uuid.Nil, so that unset proto3 fields behave as expected.
+//
+// Code generated from other messages with a bytes field named value
+// satisfies the Message interface as well.
+package pb
+
+// uuid.proto is compiled under its import path, which it's registered
+// with in the global protobuf registry, to avoid conflicts with other
+// files named uuid.proto.
+//go:generate sh -c "d=$DOLLAR(mktemp -d) && mkdir -p $DOLLAR{d}/github.com/satori/go.uuid && ln -s $DOLLAR{PWD} $DOLLAR{d}/github.com/satori/go.uuid/pb && protoc -I $DOLLAR{d} --go_out=. --go_opt=module=github.com/satori/go.uuid/pb github.com/satori/go.uuid/pb/uuid.proto; rm -rf $DOLLAR{d}"
+
+import (
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Message is implemented by generated code of messages carrying UUID
+// in a bytes field named value, such as UUID message of uuid.proto.
+type Message interface {
+	GetValue() []byte
+}
+
+// ToBytes returns value of bytes field holding u.
+// Nil UUID is encoded as empty value.
+func ToBytes(u uuid.UUID) []byte {
+	if u == uuid.Nil {
+		return nil
+	}
+	return u.Bytes()
+}
+
+// FromBytes returns UUID decoded from value of bytes field.
+// It will return error if the value is neither empty nor 16 bytes long.
+func FromBytes(b []byte) (uuid.UUID, error) {
+	if len(b) == 0 {
+		return uuid.Nil, nil
+	}
+	return uuid.FromBytes(b)
+}
+
+// ToProto returns UUID message holding u.
+// Nil UUID is encoded as empty value.
+func ToProto(u uuid.UUID) *UUID {
+	return &UUID{Value: ToBytes(u)}
+}
+
+// FromProto returns UUID decoded from UUID message m.
+// A nil message decodes to Nil UUID.
+func FromProto(m *UUID) (uuid.UUID, error) {
+	return FromBytes(m.GetValue())
+}
+
+// FromMessage returns UUID decoded from m. A nil message decodes to
+// Nil UUID, as generated getters are safe to call on nil receivers.
+func FromMessage(m Message) (uuid.UUID, error) {
+	if m == nil {
+		return uuid.Nil, nil
+	}
+	return FromBytes(m.GetValue())
+}
+
+// Validate checks that value of bytes field holds a well-formed UUID.
+func Validate(b []byte) error {
+	_, err := FromBytes(b)
+	return err
+}
+
+// ValidateRequired checks that value of bytes field holds a well-formed
+// UUID which isn't Nil, as required for mandatory identifiers.
+func ValidateRequired(b []byte) error {
+	u, err := FromBytes(b)
+	if err != nil {
+		return err
+	}
+	if u == uuid.Nil {
+		return fmt.Errorf("pb: UUID is required")
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pb
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"google.golang.org/protobuf/proto"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// message mimics code generated from uuid.proto.
+type message struct {
+	Value []byte
+}
+
+func (m *message) GetValue() []byte {
+	if m == nil {
+		return nil
+	}
+	return m.Value
+}
+
+func TestBytes(t *testing.T) {
+	b := ToBytes(uuid.NamespaceDNS)
+	assert.Equal(t, uuid.NamespaceDNS.Bytes(), b)
+
+	u, err := FromBytes(b)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS, u)
+
+	assert.Empty(t, ToBytes(uuid.Nil))
+
+	u, err = FromBytes(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, u)
+
+	_, err = FromBytes([]byte{0x01})
+	assert.Error(t, err)
+}
+
+func TestFromMessage(t *testing.T) {
+	u, err := FromMessage(&message{Value: ToBytes(uuid.NamespaceURL)})
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceURL, u)
+
+	var m *message
+	u, err = FromMessage(m)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, u)
+
+	u, err = FromMessage(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, u)
+
+	_, err = FromMessage(&message{Value: []byte{0x01}})
+	assert.Error(t, err)
+}
+
+func TestProto(t *testing.T) {
+	for _, u := range []uuid.UUID{uuid.NamespaceDNS, uuid.Max, uuid.Nil} {
+		data, err := proto.Marshal(ToProto(u))
+		require.NoError(t, err)
+
+		var m UUID
+		require.NoError(t, proto.Unmarshal(data, &m))
+		got, err := FromProto(&m)
+		require.NoError(t, err)
+		assert.Equal(t, u, got)
+
+		got, err = FromMessage(&m)
+		require.NoError(t, err)
+		assert.Equal(t, u, got)
+	}
+
+	// Nil UUID is encoded as unset field.
+	data, err := proto.Marshal(ToProto(uuid.Nil))
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	u, err := FromProto(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Nil, u)
+
+	_, err = FromProto(&UUID{Value: []byte{0x01}})
+	assert.Error(t, err)
+}
+
+func TestProtoNames(t *testing.T) {
+	// Names are qualified not to conflict in the global registry.
+	desc := (&UUID{}).ProtoReflect().Descriptor()
+	assert.Equal(t, "satori.uuid.v1.UUID", string(desc.FullName()))
+	assert.Equal(t, "github.com/satori/go.uuid/pb/uuid.proto", desc.ParentFile().Path())
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(nil))
+	assert.NoError(t, Validate(uuid.NamespaceDNS.Bytes()))
+	assert.Error(t, Validate([]byte{0x01}))
+
+	assert.NoError(t, ValidateRequired(uuid.NamespaceDNS.Bytes()))
+	assert.Error(t, ValidateRequired(nil))
+	assert.Error(t, ValidateRequired(make([]byte, 16)))
+	assert.Error(t, ValidateRequired([]byte{0x01}))
+}
@@ -0,0 +1,12 @@
+module github.com/satori/go.uuid/pgxuuid
+
+go 1.23.0
+
+require (
+	github.com/jackc/pgx/v5 v5.7.5
+	github.com/satori/go.uuid v0.0.0
+)
+
+require github.com/stretchr/testify v1.9.0 // indirect
+
+replace github.com/satori/go.uuid => ../
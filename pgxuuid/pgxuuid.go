@@ -0,0 +1,179 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package pgxuuid integrates UUIDs with pgx v5, so that uuid.UUID and
+// uuid.NullUUID values are sent to and received from PostgreSQL in the
+// 16 bytes binary wire format of its uuid type instead of text.
+//
+// Register the types on connection setup:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+package pgxuuid
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// UUID is uuid.UUID implementing pgtype.UUIDScanner and pgtype.UUIDValuer.
+type UUID uuid.UUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface.
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	if !v.Valid {
+		return fmt.Errorf("pgxuuid: cannot scan NULL into *uuid.UUID")
+	}
+	*u = v.Bytes
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u, Valid: true}, nil
+}
+
+// NullUUID is uuid.NullUUID implementing pgtype.UUIDScanner
+// and pgtype.UUIDValuer.
+type NullUUID uuid.NullUUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface.
+func (u *NullUUID) ScanUUID(v pgtype.UUID) error {
+	*u = NullUUID{UUID: v.Bytes, Valid: v.Valid}
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u NullUUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u.UUID, Valid: u.Valid}, nil
+}
+
+// TryWrapUUIDEncodePlan is a pgtype.TryWrapEncodePlanFunc encoding
+// uuid.UUID and uuid.NullUUID values through UUID and NullUUID.
+func TryWrapUUIDEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	switch value := value.(type) {
+	case uuid.UUID:
+		return &wrapUUIDEncodePlan{}, UUID(value), true
+	case uuid.NullUUID:
+		return &wrapNullUUIDEncodePlan{}, NullUUID(value), true
+	}
+	return nil, nil, false
+}
+
+type wrapUUIDEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapUUIDEncodePlan) SetNext(next pgtype.EncodePlan) {
+	plan.next = next
+}
+
+func (plan *wrapUUIDEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(UUID(value.(uuid.UUID)), buf)
+}
+
+type wrapNullUUIDEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapNullUUIDEncodePlan) SetNext(next pgtype.EncodePlan) {
+	plan.next = next
+}
+
+func (plan *wrapNullUUIDEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(NullUUID(value.(uuid.NullUUID)), buf)
+}
+
+// TryWrapUUIDScanPlan is a pgtype.TryWrapScanPlanFunc scanning into
+// *uuid.UUID and *uuid.NullUUID targets through UUID and NullUUID.
+func TryWrapUUIDScanPlan(target any) (plan pgtype.WrappedScanPlanNextSetter, nextDst any, ok bool) {
+	switch target := target.(type) {
+	case *uuid.UUID:
+		return &wrapUUIDScanPlan{}, (*UUID)(target), true
+	case *uuid.NullUUID:
+		return &wrapNullUUIDScanPlan{}, (*NullUUID)(target), true
+	}
+	return nil, nil, false
+}
+
+type wrapUUIDScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapUUIDScanPlan) SetNext(next pgtype.ScanPlan) {
+	plan.next = next
+}
+
+func (plan *wrapUUIDScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*UUID)(dst.(*uuid.UUID)))
+}
+
+type wrapNullUUIDScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapNullUUIDScanPlan) SetNext(next pgtype.ScanPlan) {
+	plan.next = next
+}
+
+func (plan *wrapNullUUIDScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*NullUUID)(dst.(*uuid.NullUUID)))
+}
+
+// UUIDCodec is pgtype.UUIDCodec decoding values into uuid.UUID.
+type UUIDCodec struct {
+	pgtype.UUIDCodec
+}
+
+// DecodeValue implements the pgtype.Codec interface.
+func (UUIDCodec) DecodeValue(tm *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var target uuid.UUID
+	scanPlan := tm.PlanScan(oid, format, &target)
+	if scanPlan == nil {
+		return nil, fmt.Errorf("pgxuuid: no scan plan for uuid.UUID")
+	}
+	if err := scanPlan.Scan(src, &target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// Register registers UUID support in tm, so that uuid.UUID and
+// uuid.NullUUID are used for PostgreSQL uuid type.
+func Register(tm *pgtype.Map) {
+	tm.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapUUIDEncodePlan}, tm.TryWrapEncodePlanFuncs...)
+	tm.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{TryWrapUUIDScanPlan}, tm.TryWrapScanPlanFuncs...)
+
+	tm.RegisterType(&pgtype.Type{
+		Name:  "uuid",
+		OID:   pgtype.UUIDOID,
+		Codec: UUIDCodec{},
+	})
+}
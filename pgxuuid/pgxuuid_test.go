@@ -0,0 +1,98 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pgxuuid
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestEncodeBinary(t *testing.T) {
+	m := newMap()
+
+	buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NamespaceDNS, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS.Bytes(), buf)
+
+	buf, err = m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NullUUID{UUID: uuid.NamespaceURL, Valid: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceURL.Bytes(), buf)
+
+	buf, err = m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NullUUID{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+}
+
+func TestScanBinary(t *testing.T) {
+	m := newMap()
+
+	var u uuid.UUID
+	err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NamespaceDNS.Bytes(), &u)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS, u)
+
+	err = m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &u)
+	assert.Error(t, err)
+
+	var nu uuid.NullUUID
+	err = m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NamespaceURL.Bytes(), &nu)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NullUUID{UUID: uuid.NamespaceURL, Valid: true}, nu)
+
+	err = m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &nu)
+	require.NoError(t, err)
+	assert.False(t, nu.Valid)
+}
+
+func TestScanText(t *testing.T) {
+	m := newMap()
+
+	var u uuid.UUID
+	err := m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, []byte(uuid.NamespaceDNS.String()), &u)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS, u)
+}
+
+func TestDecodeValue(t *testing.T) {
+	m := newMap()
+	typ, ok := m.TypeForOID(pgtype.UUIDOID)
+	require.True(t, ok)
+
+	v, err := typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, uuid.NamespaceDNS.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.NamespaceDNS, v)
+
+	v, err = typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, nil)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import "fmt"
+
+// MaxPrefixSize is the largest size of prefix accepted by NewV8WithPrefix.
+const MaxPrefixSize = 6
+
+// NewV8WithPrefix returns version 8 UUID starting with prefix, such as
+// a tenant or region identifier, with the remaining bits random.
+// UUIDs sharing a prefix are then stored close to each other and can be
+// routed by it. Layout of the UUID is as follows:
+//
+//	bytes 0 to len(prefix)-1     prefix
+//	bytes len(prefix) to 15      random, except for version and variant bits
+//
+// The prefix can't be longer than MaxPrefixSize bytes, so that it never
+// overlaps with version and variant bits. Such UUIDs aren't version 4
+// UUIDs, as not all of their bits are random.
+func NewV8WithPrefix(prefix []byte) (UUID, error) {
+	return global().NewV8WithPrefix(prefix)
+}
+
+// NewV8WithPrefix returns version 8 UUID starting with prefix.
+func (g *rfc4122Generator) NewV8WithPrefix(prefix []byte) (UUID, error) {
+	if len(prefix) > MaxPrefixSize {
+		return Nil, fmt.Errorf("uuid: prefix of %d bytes exceeds %d bytes", len(prefix), MaxPrefixSize)
+	}
+
+	u := UUID{}
+	n := copy(u[:], prefix)
+	if err := g.readFull(u[n:]); err != nil {
+		return Nil, fmt.Errorf("failed to generate random data for UUID V8: %w", err)
+	}
+	g.countGenerated(V8, 1)
+
+	return finalizeUUID(u, V8), nil
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestNewV8WithPrefix(t *testing.T) {
+	prefix := []byte{0xca, 0xfe, 0xba, 0xbe, 0x00, 0x01}
+
+	u1, err := NewV8WithPrefix(prefix)
+	require.NoError(t, err)
+	assert.Equal(t, V8, u1.Version())
+	assert.Equal(t, VariantRFC4122, u1.Variant())
+	assert.Equal(t, prefix, u1[:6])
+
+	u2, err := NewV8WithPrefix(prefix[:2])
+	require.NoError(t, err)
+	assert.Equal(t, prefix[:2], u2[:2])
+	assert.NotEqual(t, u1, u2)
+
+	u3, err := NewV8WithPrefix(nil)
+	require.NoError(t, err)
+	assert.Equal(t, V8, u3.Version())
+
+	_, err = NewV8WithPrefix(make([]byte, MaxPrefixSize+1))
+	assert.Error(t, err)
+}
+
+func TestNewV8WithPrefixFaultyRand(t *testing.T) {
+	g := newRFC4122Generator()
+	g.rand = &faultyReader{}
+
+	u, err := g.NewV8WithPrefix([]byte{0x01})
+	require.Error(t, err)
+	assert.Equal(t, Nil, u)
+}
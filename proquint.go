@@ -0,0 +1,95 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Proquint alphabet: each 16-bit word is spelled as consonant, vowel,
+// consonant, vowel, consonant, using 4 bits per consonant and 2 bits
+// per vowel.
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// Length of proquint representation: 8 words of 5 letters and 7 dashes.
+const proquintLen = 8*5 + 7
+
+// Proquint returns pronounceable representation of UUID made of eight
+// proquint words separated by dashes, e.g.
+// "lusab-babad-gutih-tugad-gutuk-bisog-mudof-sakat". It's meant to be
+// read aloud, e.g. over the phone.
+func (u UUID) Proquint() string {
+	var sb strings.Builder
+	sb.Grow(proquintLen)
+	for i := 0; i < Size; i += 2 {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+		w := uint16(u[i])<<8 | uint16(u[i+1])
+		sb.WriteByte(proquintConsonants[w>>12&0x0f])
+		sb.WriteByte(proquintVowels[w>>10&0x03])
+		sb.WriteByte(proquintConsonants[w>>6&0x0f])
+		sb.WriteByte(proquintVowels[w>>4&0x03])
+		sb.WriteByte(proquintConsonants[w&0x0f])
+	}
+	return sb.String()
+}
+
+// FromProquint returns UUID parsed from representation returned by
+// Proquint. Letters are accepted in any case.
+func FromProquint(input string) (UUID, error) {
+	if len(input) != proquintLen {
+		return Nil, fmt.Errorf("uuid: incorrect proquint length: %s", errorInput(input))
+	}
+
+	u := UUID{}
+	for i := 0; i < Size/2; i++ {
+		word := input[i*6 : i*6+5]
+		if i > 0 && input[i*6-1] != '-' {
+			return Nil, fmt.Errorf("uuid: incorrect proquint format: %s", errorInput(input))
+		}
+
+		var w uint16
+		for j := 0; j < len(word); j++ {
+			alphabet, bitSize := proquintConsonants, 4
+			if j%2 == 1 {
+				alphabet, bitSize = proquintVowels, 2
+			}
+			// Lowercase ASCII only, keeping byte offsets intact.
+			c := word[j]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			idx := strings.IndexByte(alphabet, c)
+			if idx < 0 {
+				return Nil, fmt.Errorf("uuid: incorrect proquint format: %s", errorInput(input))
+			}
+			w = w<<bitSize | uint16(idx)
+		}
+		u[i*2], u[i*2+1] = byte(w>>8), byte(w)
+	}
+	return u, nil
+}
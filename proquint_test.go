@@ -0,0 +1,69 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestProquint(t *testing.T) {
+	// 127.0.0.1 is "lusab-babad" in proquint specification.
+	u := UUID{0x7f, 0x00, 0x00, 0x01}
+	assert.Equal(t, "lusab-babad-babab-babab-babab-babab-babab-babab", u.Proquint())
+	assert.Equal(t, "babab-babab-babab-babab-babab-babab-babab-babab", Nil.Proquint())
+	assert.Equal(t, "zuzuz-zuzuz-zuzuz-zuzuz-zuzuz-zuzuz-zuzuz-zuzuz", Max.Proquint())
+}
+
+func TestFromProquint(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		u := MustNewV4()
+		u1, err := FromProquint(u.Proquint())
+		require.NoError(t, err)
+		assert.Equal(t, u, u1)
+	}
+
+	u, err := FromProquint(strings.ToUpper(NamespaceDNS.Proquint()))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u)
+}
+
+func TestFromProquintInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"lusab-babad",
+		"lusab_babad-babab-babab-babab-babab-babab-babab",
+		"lusab-babad-babab-babab-babab-babab-babab-babaa",
+		"lusab-babad-babab-babab-babab-babab-babab-bbbab",
+		// Non-ASCII of the same byte length, shrinking when lowercased.
+		"\u212aab-babad-babab-babab-babab-babab-babab-babab",
+		"lusab-babad-babab-babab-babab-babab-babab-ba\u212a",
+		"lusab-babad-babab-babab-babab-babab-babab-bab\u00e9",
+	}
+	for _, input := range tests {
+		_, err := FromProquint(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Number of bits of version 7 UUID rand_a field used
+// as a counter by ReserveV7.
+const v7CounterBits = 12
+
+// MaxV7Reservation is the maximal number of UUIDs reserved by a single
+// call to ReserveV7. It limits how far timestamps of a block may run
+// ahead of the clock, to 256 milliseconds.
+const MaxV7Reservation = 1 << 20
+
+// BatchGenerator provides interface for reserving blocks of
+// time ordered UUIDs. Generators returned by NewGenerator implement it.
+type BatchGenerator interface {
+	ReserveV7(n int) ([]UUID, error)
+}
+
+// ReserveV7 returns a block of n strictly increasing version 7 UUIDs,
+// so that rows of a bulk insert can be assigned ordered IDs in advance.
+func ReserveV7(n int) ([]UUID, error) {
+	return global().ReserveV7(n)
+}
+
+// ReserveV7 returns a block of n strictly increasing version 7 UUIDs.
+// The block is reserved atomically: 12 bits of rand_a field hold
+// a counter starting from zero every millisecond, and once it overflows
+// the timestamp is advanced. UUIDs of the block are greater than
+// the ones of any block reserved earlier by the generator.
+func (g *rfc4122Generator) ReserveV7(n int) ([]UUID, error) {
+	if n <= 0 || n > MaxV7Reservation {
+		return nil, fmt.Errorf("uuid: number of reserved UUIDs must be in range [1, %d], got %d", MaxV7Reservation, n)
+	}
+
+	// Random bits of rand_b fields, 8 bytes per UUID.
+	random := make([]byte, 8*n)
+	if err := g.readFull(random); err != nil {
+		return nil, fmt.Errorf("failed to generate random data for UUID V7: %w", err)
+	}
+
+	timeNow, err := g.getV7Time()
+	if err != nil {
+		return nil, err
+	}
+
+	g.storageMutex.Lock()
+	start := timeNow << v7CounterBits
+	if start <= g.v7LastReserved {
+		start = g.v7LastReserved + 1
+	}
+	g.v7LastReserved = start + uint64(n) - 1
+	g.storageMutex.Unlock()
+	g.countGenerated(V7, uint64(n))
+
+	res := make([]UUID, n)
+	for i := range res {
+		u := &res[i]
+		v := start + uint64(i)
+		putUint48(u[:6], v>>v7CounterBits)
+		binary.BigEndian.PutUint16(u[6:], uint16(v&(1<<v7CounterBits-1)))
+		copy(u[8:], random[8*i:])
+		g.putV7NodeID(u)
+		u.SetVersion(V7)
+		u.SetVariant(VariantRFC4122)
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestReserveV7(t *testing.T) {
+	us, err := ReserveV7(100)
+	require.NoError(t, err)
+	require.Len(t, us, 100)
+	for i, u := range us {
+		assert.Equal(t, V7, u.Version())
+		assert.Equal(t, VariantRFC4122, u.Variant())
+		if i > 0 {
+			assert.Equal(t, -1, compareUUID(us[i-1], u), "%s >= %s", us[i-1], u)
+		}
+	}
+
+	var g BatchGenerator = NewGenerator().(*rfc4122Generator)
+	_, err = g.ReserveV7(0)
+	assert.Error(t, err)
+	_, err = g.ReserveV7(MaxV7Reservation + 1)
+	assert.Error(t, err)
+}
+
+func TestReserveV7Blocks(t *testing.T) {
+	ts := time.UnixMilli(1686000000000)
+	g := newRFC4122Generator()
+	g.epochFunc = func() time.Time { return ts }
+
+	// Counter overflows into the following milliseconds.
+	b1, err := g.ReserveV7(5000)
+	require.NoError(t, err)
+	assert.Equal(t, ts, v7Time(t, b1[0]))
+	assert.Equal(t, []byte{0x70, 0x00}, b1[0][6:8])
+	assert.Equal(t, ts, v7Time(t, b1[4095]))
+	assert.Equal(t, []byte{0x7f, 0xff}, b1[4095][6:8])
+	assert.Equal(t, ts.Add(time.Millisecond), v7Time(t, b1[4096]))
+	assert.Equal(t, []byte{0x70, 0x00}, b1[4096][6:8])
+
+	// Next block continues after the previous one,
+	// even though the clock didn't move.
+	b2, err := g.ReserveV7(2)
+	require.NoError(t, err)
+	assert.Equal(t, -1, compareUUID(b1[len(b1)-1], b2[0]))
+	assert.Equal(t, []byte{0x73, 0x88}, b2[0][6:8])
+
+	// Clock moving forward resets the counter.
+	ts = ts.Add(time.Second)
+	b3, err := g.ReserveV7(1)
+	require.NoError(t, err)
+	assert.Equal(t, ts, v7Time(t, b3[0]))
+	assert.Equal(t, []byte{0x70, 0x00}, b3[0][6:8])
+}
+
+func TestReserveV7FaultyRand(t *testing.T) {
+	g := newRFC4122Generator()
+	g.rand = &faultyReader{}
+	_, err := g.ReserveV7(1)
+	assert.Error(t, err)
+}
+
+func compareUUID(a, b UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+func v7Time(t *testing.T, u UUID) time.Time {
+	ts, err := TimestampFromV7(u)
+	require.NoError(t, err)
+	return ts
+}
+
+func BenchmarkReserveV7(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ReserveV7(1000)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build uuid_selfcheck
+
+package uuid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+// With uuid_selfcheck build tag, every binary and test importing
+// the package verifies layouts of generated UUIDs on startup.
+func init() {
+	if err := verifyLayouts(); err != nil {
+		panic(err)
+	}
+}
+
+// verifyLayouts regenerates RFC 9562 test vectors and checks that
+// generated UUIDs and timestamps decoded from them match published
+// values, catching any regression in placement of fields.
+func verifyLayouts() error {
+	newGen := func(random []byte) *rfc4122Generator {
+		g := newRFC4122Generator()
+		g.epochFunc = func() time.Time { return vectors.Time }
+		g.hwAddrFunc = func() (net.HardwareAddr, error) { return vectors.Node, nil }
+		g.rand = bytes.NewReader(random)
+		return g
+	}
+
+	checks := []struct {
+		version  byte
+		expected string
+		generate func() (UUID, error)
+	}{
+		{V1, vectors.V1, newGen(vectors.ClockSeq).newV1},
+		{V3, vectors.V3, func() (UUID, error) { return NewV3(NamespaceDNS, vectors.Name), nil }},
+		{V5, vectors.V5, func() (UUID, error) { return NewV5(NamespaceDNS, vectors.Name), nil }},
+		{V6, vectors.V6, newGen(vectors.ClockSeq).NewV6},
+		{V7, vectors.V7, newGen(vectors.V7Rand).NewV7},
+		{V8, vectors.V8, func() (UUID, error) { return NewV8Hash(NamespaceDNS, vectors.Name, sha256.New), nil }},
+	}
+	for _, c := range checks {
+		u, err := c.generate()
+		if err != nil {
+			return fmt.Errorf("uuid: layout check of version %d: %w", c.version, err)
+		}
+		if got := u.String(); got != c.expected {
+			return fmt.Errorf("uuid: layout check of version %d: generated %s, expected %s", c.version, got, c.expected)
+		}
+		if c.version == V3 || c.version == V5 || c.version == V8 {
+			continue
+		}
+		ts, err := timeOf(u)
+		if err != nil {
+			return fmt.Errorf("uuid: layout check of version %d: %w", c.version, err)
+		}
+		if !ts.Equal(vectors.Time) {
+			return fmt.Errorf("uuid: layout check of version %d: decoded time %s, expected %s", c.version, ts.UTC(), vectors.Time)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build uuid_selfcheck
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+func TestVerifyLayouts(t *testing.T) {
+	require.NoError(t, verifyLayouts())
+}
+
+func TestVerifyLayoutsDetectsRegression(t *testing.T) {
+	expected := vectors.V6
+	defer func() { vectors.V6 = expected }()
+
+	// Layout of V6 before it was fixed, with time fields in V1 order.
+	vectors.V6 = "c232ab00-9414-6ec1-b3c8-9f6bdeced846"
+	err := verifyLayouts()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "version 6")
+	assert.Contains(t, err.Error(), expected)
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"context"
+	"iter"
+)
+
+// SeqGenerator provides interface for iterating over generated UUIDs,
+// see Seq. Generators returned by NewGenerator implement it.
+//
+//	for u, err := range gen.(uuid.SeqGenerator).V7Seq(ctx) {
+//		...
+//	}
+type SeqGenerator interface {
+	V4Seq(ctx context.Context) iter.Seq2[UUID, error]
+	V7Seq(ctx context.Context) iter.Seq2[UUID, error]
+}
+
+// Seq returns an iterator over UUIDs produced by newFn, such as NewV4
+// or method of a Generator. The iteration stops after ctx is done or
+// newFn fails, yielding the corresponding error as the last value.
+//
+//	for u, err := range uuid.Seq(ctx, gen.NewV7) {
+//		...
+//	}
+func Seq(ctx context.Context, newFn func() (UUID, error)) iter.Seq2[UUID, error] {
+	return func(yield func(UUID, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Nil, err)
+				return
+			}
+			u, err := newFn()
+			if !yield(u, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// V4Seq returns an iterator over random generated UUIDs.
+// Same behavior as Seq called with NewV4.
+func V4Seq(ctx context.Context) iter.Seq2[UUID, error] {
+	return global().V4Seq(ctx)
+}
+
+// V7Seq returns an iterator over UUIDs v7.
+// Same behavior as Seq called with NewV7.
+func V7Seq(ctx context.Context) iter.Seq2[UUID, error] {
+	return global().V7Seq(ctx)
+}
+
+// V4Seq returns an iterator over random generated UUIDs,
+// see package-level V4Seq.
+func (g *rfc4122Generator) V4Seq(ctx context.Context) iter.Seq2[UUID, error] {
+	return Seq(ctx, g.NewV4)
+}
+
+// V7Seq returns an iterator over UUIDs v7, see package-level V7Seq.
+func (g *rfc4122Generator) V7Seq(ctx context.Context) iter.Seq2[UUID, error] {
+	return Seq(ctx, g.NewV7)
+}
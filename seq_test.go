@@ -0,0 +1,111 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestV4Seq(t *testing.T) {
+	ids := NewSet()
+	for u, err := range V4Seq(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, V4, u.Version())
+		ids.Add(u)
+		if ids.Len() == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, ids.Len())
+}
+
+func TestV7Seq(t *testing.T) {
+	n := 0
+	for u, err := range V7Seq(context.Background()) {
+		require.NoError(t, err)
+		assert.Equal(t, V7, u.Version())
+		n++
+		if n == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, n)
+}
+
+func TestGeneratorV7Seq(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithClock(&fakeClock{now: ts}))
+
+	n := 0
+	for u, err := range g.(SeqGenerator).V7Seq(context.Background()) {
+		require.NoError(t, err)
+		got, err := TimestampFromV7(u)
+		require.NoError(t, err)
+		assert.True(t, ts.Equal(got))
+		n++
+		if n == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, n)
+}
+
+func TestSeqCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n := 0
+	var lastErr error
+	for _, err := range Seq(ctx, NewV4) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		n++
+		if n == 3 {
+			cancel()
+		}
+	}
+	assert.Equal(t, 3, n)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
+
+func TestSeqGeneratorError(t *testing.T) {
+	g := &rfc4122Generator{
+		epochFunc:  time.Now,
+		hwAddrFunc: defaultHWAddrFunc,
+		rand:       &faultyReader{readToFail: 1},
+	}
+
+	var errs []error
+	for _, err := range Seq(context.Background(), g.NewV4) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+}
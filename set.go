@@ -0,0 +1,134 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+)
+
+// Set is a collection of unique UUIDs.
+// The zero value is an empty set ready to be read, use NewSet or make
+// to get a set ready to be modified.
+type Set map[UUID]struct{}
+
+// NewSet returns a Set holding given UUIDs.
+func NewSet(ids ...UUID) Set {
+	s := make(Set, len(ids))
+	for _, u := range ids {
+		s[u] = struct{}{}
+	}
+	return s
+}
+
+// Add adds given UUIDs to the set.
+func (s Set) Add(ids ...UUID) {
+	for _, u := range ids {
+		s[u] = struct{}{}
+	}
+}
+
+// Remove removes given UUIDs from the set.
+func (s Set) Remove(ids ...UUID) {
+	for _, u := range ids {
+		delete(s, u)
+	}
+}
+
+// Contains returns true if u is a member of the set.
+func (s Set) Contains(u UUID) bool {
+	_, ok := s[u]
+	return ok
+}
+
+// Len returns number of UUIDs in the set.
+func (s Set) Len() int {
+	return len(s)
+}
+
+// Union returns a new set holding UUIDs present in s or other.
+func (s Set) Union(other Set) Set {
+	res := make(Set, len(s)+len(other))
+	for u := range s {
+		res[u] = struct{}{}
+	}
+	for u := range other {
+		res[u] = struct{}{}
+	}
+	return res
+}
+
+// Intersection returns a new set holding UUIDs present in both s and other.
+func (s Set) Intersection(other Set) Set {
+	if len(other) < len(s) {
+		s, other = other, s
+	}
+	res := make(Set)
+	for u := range s {
+		if _, ok := other[u]; ok {
+			res[u] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Difference returns a new set holding UUIDs present in s but not in other.
+func (s Set) Difference(other Set) Set {
+	res := make(Set)
+	for u := range s {
+		if _, ok := other[u]; !ok {
+			res[u] = struct{}{}
+		}
+	}
+	return res
+}
+
+// Slice returns members of the set sorted in byte order.
+func (s Set) Slice() []UUID {
+	res := make([]UUID, 0, len(s))
+	for u := range s {
+		res = append(res, u)
+	}
+	slices.SortFunc(res, func(a, b UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return res
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The set is encoded as an array of canonical UUID strings
+// sorted in byte order.
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts an array of strings in any form supported by UnmarshalText.
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var ids []UUID
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	*s = NewSet(ids...)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestSet(t *testing.T) {
+	s := NewSet(NamespaceDNS, NamespaceURL, NamespaceDNS)
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Contains(NamespaceDNS))
+	assert.False(t, s.Contains(NamespaceOID))
+
+	s.Add(NamespaceOID)
+	assert.True(t, s.Contains(NamespaceOID))
+
+	s.Remove(NamespaceDNS)
+	assert.False(t, s.Contains(NamespaceDNS))
+	assert.Equal(t, []UUID{NamespaceURL, NamespaceOID}, s.Slice())
+}
+
+func TestSetOperations(t *testing.T) {
+	s1 := NewSet(NamespaceDNS, NamespaceURL)
+	s2 := NewSet(NamespaceURL, NamespaceOID)
+
+	assert.Equal(t, NewSet(NamespaceDNS, NamespaceURL, NamespaceOID), s1.Union(s2))
+	assert.Equal(t, NewSet(NamespaceURL), s1.Intersection(s2))
+	assert.Equal(t, NewSet(NamespaceDNS), s1.Difference(s2))
+}
+
+func TestSetJSON(t *testing.T) {
+	s := NewSet(NamespaceURL, NamespaceDNS)
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `["6ba7b810-9dad-11d1-80b4-00c04fd430c8","6ba7b811-9dad-11d1-80b4-00c04fd430c8"]`, string(data))
+
+	var s1 Set
+	err = json.Unmarshal(data, &s1)
+	require.NoError(t, err)
+	assert.Equal(t, s, s1)
+
+	err = json.Unmarshal([]byte(`["invalid"]`), &s1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MaxShardHint is the largest shard hint which can be embedded
+// into UUID by NewV8WithShard.
+const MaxShardHint = 0x0fff
+
+// ShardN deterministically maps UUID to one of n shards, returning
+// a number in [0, n). Only the last 62 bits, which are random in
+// version 4 and version 7 UUIDs, are used, so the version and variant
+// bits don't skew the distribution. It panics if n <= 0.
+func (u UUID) ShardN(n int) int {
+	if n <= 0 {
+		panic("uuid: invalid argument to ShardN")
+	}
+	lo := binary.BigEndian.Uint64(u[8:]) & 0x3fffffffffffffff
+	return int(lo % uint64(n))
+}
+
+// NewV8WithShard returns version 8 UUID embedding shard hint.
+// Same layout as version 7 UUID is used, with the shard hint stored
+// in 12 bits following the version, so the UUIDs stay time-ordered.
+// Use ShardHint to extract the shard hint.
+func NewV8WithShard(shard uint16) (UUID, error) {
+	return global().NewV8WithShard(shard)
+}
+
+// NewV8WithShard returns version 8 UUID embedding shard hint.
+func (g *rfc4122Generator) NewV8WithShard(shard uint16) (UUID, error) {
+	if shard > MaxShardHint {
+		return Nil, fmt.Errorf("uuid: shard hint %d exceeds %d", shard, MaxShardHint)
+	}
+
+	u := UUID{}
+	timeNow, err := g.getV7Time()
+	if err != nil {
+		return Nil, err
+	}
+	putUint48(u[:6], timeNow)
+	binary.BigEndian.PutUint16(u[6:], shard)
+
+	if err := g.readFull(u[8:]); err != nil {
+		return Nil, fmt.Errorf("failed to generate random data for UUID V8: %w", err)
+	}
+	g.countGenerated(V8, 1)
+
+	return finalizeUUID(u, V8), nil
+}
+
+// ShardHint returns shard hint embedded into UUID by NewV8WithShard.
+// It will return error if UUID isn't of version 8.
+func ShardHint(u UUID) (uint16, error) {
+	if u.Version() != V8 {
+		return 0, fmt.Errorf("uuid: expected version %d, got version %d", V8, u.Version())
+	}
+	return binary.BigEndian.Uint16(u[6:]) & MaxShardHint, nil
+}
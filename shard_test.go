@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestShardN(t *testing.T) {
+	u := MustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	assert.Equal(t, int(0x00b400c04fd430c8%16), u.ShardN(16))
+	assert.Equal(t, u.ShardN(16), u.ShardN(16))
+	assert.Equal(t, 0, u.ShardN(1))
+
+	// Variant bits don't affect the shard.
+	u1 := u
+	u1[8] ^= 0xc0
+	assert.Equal(t, u.ShardN(7), u1.ShardN(7))
+
+	assert.Panics(t, func() {
+		u.ShardN(0)
+	})
+}
+
+func TestShardNDistribution(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 4000; i++ {
+		counts[MustNewV4().ShardN(4)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 1000, c, 200)
+	}
+}
+
+func TestNewV8WithShard(t *testing.T) {
+	u, err := NewV8WithShard(42)
+	require.NoError(t, err)
+	assert.Equal(t, V8, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+
+	shard, err := ShardHint(u)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(42), shard)
+
+	u, err = NewV8WithShard(MaxShardHint)
+	require.NoError(t, err)
+	shard, err = ShardHint(u)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(MaxShardHint), shard)
+
+	_, err = NewV8WithShard(MaxShardHint + 1)
+	assert.Error(t, err)
+
+	_, err = ShardHint(MustNewV7())
+	assert.Error(t, err)
+}
+
+func TestNewV8WithShardFaultyRand(t *testing.T) {
+	g := newRFC4122Generator()
+	g.rand = &faultyReader{}
+
+	u, err := g.NewV8WithShard(1)
+	require.Error(t, err)
+	assert.Equal(t, Nil, u)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Prefixes of SortKey telling how it's ordered.
+const (
+	sortKeyHash = 'h'
+	sortKeyTime = 't'
+)
+
+// SortKeyLen is length of keys returned by SortKey.
+const SortKeyLen = 1 + 16 + 32
+
+// SortKey returns fixed width key of UUID, whose lexical order follows
+// creation time of time-based UUIDs regardless of their version, for
+// building secondary indexes in key-value stores holding UUIDs of mixed
+// versions.
+//
+// Key of version 1, 6 and 7 UUID is 't' followed by its timestamp as
+// 16 hex digits of 100 nanoseconds intervals since 1582-10-15, the
+// resolution of version 1 and 6 UUIDs; version 7 timestamp is assumed
+// to count from Unix epoch. Key of UUID of any other version is 'h'
+// followed by 16 hex digits of Hash64, spreading such keys evenly. Both
+// are followed by 32 hex digits of UUID itself, keeping keys unique.
+// Keys of UUIDs without timestamp sort before all timestamped keys.
+func SortKey(u UUID) string {
+	var buf [SortKeyLen]byte
+	var prefix uint64
+	switch u.Version() {
+	case V1:
+		buf[0], prefix = sortKeyTime, timestampV1(u)
+	case V6:
+		buf[0], prefix = sortKeyTime, timestampV6(u)
+	case V7:
+		buf[0], prefix = sortKeyTime, epochStart+getUint48(u[:6])*10000
+	default:
+		buf[0], prefix = sortKeyHash, u.Hash64()
+	}
+
+	var b [8 + Size]byte
+	binary.BigEndian.PutUint64(b[:], prefix)
+	copy(b[8:], u[:])
+	for i, c := range b {
+		buf[1+2*i] = hexDigits[c>>4]
+		buf[2+2*i] = hexDigits[c&0x0f]
+	}
+	return string(buf[:])
+}
+
+// FromSortKey returns UUID stored in key returned by SortKey.
+func FromSortKey(key string) (UUID, error) {
+	if len(key) != SortKeyLen || (key[0] != sortKeyTime && key[0] != sortKeyHash) {
+		return Nil, fmt.Errorf("uuid: invalid sort key %q", errorInput(key))
+	}
+	var u UUID
+	if err := u.decodeHashLike([]byte(key[17:]), 0); err != nil {
+		return Nil, fmt.Errorf("uuid: invalid sort key %q", errorInput(key))
+	}
+	if SortKey(u) != key {
+		return Nil, fmt.Errorf("uuid: invalid sort key %q", errorInput(key))
+	}
+	return u, nil
+}
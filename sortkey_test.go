@@ -0,0 +1,105 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+func TestSortKey(t *testing.T) {
+	var node [6]byte
+	copy(node[:], vectors.Node)
+
+	v7 := Must(FromString(vectors.V7))
+	key := SortKey(v7)
+	assert.Len(t, key, SortKeyLen)
+	assert.Equal(t, "t01ec9414c232ab00"+v7.StringCompact(), key)
+
+	// Timestamps of the same instant agree across versions.
+	assert.Equal(t, key[:17], SortKey(Must(NewV1At(vectors.Time, 0, node)))[:17])
+	assert.Equal(t, key[:17], SortKey(Must(NewV6At(vectors.Time, 0, node)))[:17])
+
+	v4 := Must(NewV4())
+	assert.Equal(t, byte('h'), SortKey(v4)[0])
+	assert.Len(t, SortKey(v4), SortKeyLen)
+}
+
+func TestSortKeyOrder(t *testing.T) {
+	var node [6]byte
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Created in order, but with different versions.
+	ids := []UUID{
+		Must(NewV1At(start, 0, node)),
+		Must(NewV6At(start.Add(time.Millisecond), 0, node)),
+		newV7At(t, start.Add(2*time.Millisecond)),
+		Must(NewV1At(start.Add(3*time.Millisecond), 0, node)),
+	}
+	keys := make([]string, len(ids))
+	for i, u := range ids {
+		keys[i] = SortKey(u)
+	}
+	assert.True(t, sort.StringsAreSorted(keys))
+
+	// Canonical text of mixed versions isn't sorted.
+	text := make([]string, len(ids))
+	for i, u := range ids {
+		text[i] = u.String()
+	}
+	assert.False(t, slices.IsSorted(text))
+}
+
+func TestFromSortKey(t *testing.T) {
+	for _, u := range []UUID{Must(NewV4()), Must(NewV7()), Must(NewV1()), Nil} {
+		u2, err := FromSortKey(SortKey(u))
+		require.NoError(t, err)
+		assert.Equal(t, u, u2)
+	}
+
+	key := SortKey(Must(NewV7()))
+	for _, invalid := range []string{
+		"",
+		key[1:],
+		"x" + key[1:],
+		"h" + key[1:],
+		key[:SortKeyLen-1] + "z",
+	} {
+		_, err := FromSortKey(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// Returns version 7 UUID holding timestamp ts.
+func newV7At(t *testing.T, ts time.Time) UUID {
+	g := newRFC4122Generator()
+	g.epochFunc = func() time.Time { return ts }
+	u, err := g.NewV7()
+	require.NoError(t, err)
+	return u
+}
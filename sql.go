@@ -22,7 +22,9 @@
 package uuid
 
 import (
+	"bytes"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 )
 
@@ -33,8 +35,9 @@ func (u UUID) Value() (driver.Value, error) {
 }
 
 // Scan implements the sql.Scanner interface.
-// A 16-byte slice is handled by UnmarshalBinary, while
-// a longer byte slice or a string is handled by UnmarshalText.
+// A 16-byte slice or array is handled by UnmarshalBinary, while
+// a longer byte slice, a string, a non-nil string pointer or
+// a fmt.Stringer is handled by UnmarshalText.
 func (u *UUID) Scan(src interface{}) error {
 	switch src := src.(type) {
 	case []byte:
@@ -43,14 +46,60 @@ func (u *UUID) Scan(src interface{}) error {
 		}
 		return u.UnmarshalText(src)
 
+	case [Size]byte:
+		return u.UnmarshalBinary(src[:])
+
 	case string:
 		return u.UnmarshalText([]byte(src))
 
+	case *string:
+		if src == nil {
+			return fmt.Errorf("uuid: cannot convert nil %T to UUID", src)
+		}
+		return u.UnmarshalText([]byte(*src))
+
+	case fmt.Stringer:
+		return u.UnmarshalText([]byte(src.String()))
+
 	default:
 		return fmt.Errorf("uuid: cannot convert %T to UUID", src)
 	}
 }
 
+// ValueFormat selects representation of UUID stored in the database,
+// as databases and drivers differ in their optimal storage
+// representation. Each representation has its own type, see Valuer.
+type ValueFormat int
+
+const (
+	// TextFormat is canonical string representation, as returned by String.
+	TextFormat ValueFormat = iota
+	// CompactFormat is string of 32 hexadecimal digits without dashes,
+	// as returned by StringCompact.
+	CompactFormat
+	// BinaryFormat is 16 raw bytes, suitable for BINARY(16) columns.
+	BinaryFormat
+	// GUIDFormat is 16 bytes in the layout of Windows GUID structure,
+	// with the first three fields byte-swapped, as stored by SQL Server
+	// in uniqueidentifier columns, see LegacyGUID.
+	GUIDFormat
+)
+
+// Valuer returns u converted to the type storing it in representation f:
+// NullUUID, NullCompactUUID, NullBinaryUUID or NullGUID.
+func (f ValueFormat) Valuer(u NullUUID) driver.Valuer {
+	switch f {
+	case CompactFormat:
+		return NullCompactUUID(u)
+	case BinaryFormat:
+		return NullBinaryUUID(u)
+	case GUIDFormat:
+		return NullGUID(u)
+	default:
+		return u
+	}
+}
+
 // NullUUID can be used with the standard sql package to represent a
 // UUID value that can be NULL in the database
 type NullUUID struct {
@@ -59,7 +108,8 @@ type NullUUID struct {
 }
 
 // Value implements the driver.Valuer interface.
-// It returns the UUID string value if valid, otherwise it returns nil.
+// It returns the UUID string representation if valid,
+// otherwise it returns nil.
 func (u NullUUID) Value() (driver.Value, error) {
 	if !u.Valid {
 		return nil, nil
@@ -67,13 +117,334 @@ func (u NullUUID) Value() (driver.Value, error) {
 	return u.UUID.Value()
 }
 
+// CompactUUID is a UUID stored in the database as 32 hexadecimal
+// digits without dashes, typically in a CHAR(32) column.
+type CompactUUID UUID
+
+// Value implements the driver.Valuer interface.
+// It returns the UUID string representation without dashes.
+func (u CompactUUID) Value() (driver.Value, error) {
+	return UUID(u).StringCompact(), nil
+}
+
 // Scan implements the sql.Scanner interface.
+// It accepts the same inputs as UUID.Scan.
+func (u *CompactUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns canonical string representation of UUID.
+func (u CompactUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It returns canonical string representation, as UUID does.
+func (u CompactUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText.
+func (u *CompactUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
+
+// BinaryUUID is a UUID stored in the database as its 16 raw bytes,
+// typically in a BINARY(16) or BLOB column.
+type BinaryUUID UUID
+
+// Value implements the driver.Valuer interface.
+// It returns 16 bytes of UUID.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return UUID(u).Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as UUID.Scan.
+func (u *BinaryUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns canonical string representation of UUID.
+func (u BinaryUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It returns canonical string representation, as UUID does.
+func (u BinaryUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText.
+func (u *BinaryUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
+
+// Equal returns true if both u and other are NULL or both are valid and
+// hold equal UUIDs, otherwise returns false. UUID of a NULL value is
+// ignored, unlike when comparing with ==.
+func (u NullUUID) Equal(other NullUUID) bool {
+	if !u.Valid || !other.Valid {
+		return u.Valid == other.Valid
+	}
+	return u.UUID == other.UUID
+}
+
+// Scan implements the sql.Scanner interface.
+// NULL is scanned as invalid value, anything else as by UUID.Scan.
+// Value is left intact if src can't be scanned.
 func (u *NullUUID) Scan(src interface{}) error {
 	if src == nil {
 		u.UUID, u.Valid = Nil, false
 		return nil
 	}
 
+	var res UUID
+	if err := res.Scan(src); err != nil {
+		return err
+	}
+	u.UUID, u.Valid = res, true
+	return nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// A valid UUID is encoded as by UUID.MarshalText, while NULL
+// is encoded as empty text. JSON encoding is done by MarshalJSON.
+func (u NullUUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return u.UUID.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text decodes as NULL, any other text is decoded as by
+// UUID.UnmarshalText.
+func (u *NullUUID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		u.UUID, u.Valid = Nil, false
+		return nil
+	}
+	if err := u.UUID.UnmarshalText(text); err != nil {
+		return err
+	}
 	u.Valid = true
-	return u.UUID.Scan(src)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// A valid UUID is encoded as by UUID.MarshalJSON, while NULL is encoded
+// as JSON null, rather than as empty string returned by MarshalText.
+func (u NullUUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return u.UUID.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// JSON null and empty string decode as NULL, any other string is
+// decoded as by UUID.UnmarshalText. The object form used by previous
+// releases, like {"UUID":"6ba7b810-...","Valid":true}, is accepted too.
+func (u *NullUUID) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		u.UUID, u.Valid = Nil, false
+		return nil
+	}
+	if len(data) > 0 && data[0] == '{' {
+		var legacy struct {
+			UUID  UUID
+			Valid bool
+		}
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return err
+		}
+		u.UUID, u.Valid = legacy.UUID, legacy.Valid
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(text))
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// A valid UUID is encoded as its 16 bytes, while NULL is encoded
+// as empty data.
+func (u NullUUID) MarshalBinary() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return u.UUID.MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// Empty data decodes as NULL, any other data is decoded as by
+// UUID.UnmarshalBinary.
+func (u *NullUUID) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		u.UUID, u.Valid = Nil, false
+		return nil
+	}
+	if err := u.UUID.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	u.Valid = true
+	return nil
+}
+
+// NullCompactUUID is a NullUUID stored in the database as 32 hexadecimal
+// digits without dashes, see CompactUUID.
+type NullCompactUUID NullUUID
+
+// Value implements the driver.Valuer interface.
+// It returns the UUID string representation without dashes if valid,
+// otherwise it returns nil.
+func (u NullCompactUUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return CompactUUID(u.UUID).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as NullUUID.Scan.
+func (u *NullCompactUUID) Scan(src interface{}) error {
+	return (*NullUUID)(u).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It encodes u as NullUUID does.
+func (u NullCompactUUID) MarshalText() ([]byte, error) {
+	return NullUUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalText.
+func (u *NullCompactUUID) UnmarshalText(text []byte) error {
+	return (*NullUUID)(u).UnmarshalText(text)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It encodes u as NullUUID does.
+func (u NullCompactUUID) MarshalJSON() ([]byte, error) {
+	return NullUUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalJSON.
+func (u *NullCompactUUID) UnmarshalJSON(data []byte) error {
+	return (*NullUUID)(u).UnmarshalJSON(data)
+}
+
+// NullBinaryUUID is a NullUUID stored in the database as 16 raw bytes,
+// see BinaryUUID.
+type NullBinaryUUID NullUUID
+
+// Value implements the driver.Valuer interface.
+// It returns 16 bytes of UUID if valid, otherwise it returns nil.
+func (u NullBinaryUUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return BinaryUUID(u.UUID).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as NullUUID.Scan.
+func (u *NullBinaryUUID) Scan(src interface{}) error {
+	return (*NullUUID)(u).Scan(src)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It encodes u as NullUUID does.
+func (u NullBinaryUUID) MarshalText() ([]byte, error) {
+	return NullUUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalText.
+func (u *NullBinaryUUID) UnmarshalText(text []byte) error {
+	return (*NullUUID)(u).UnmarshalText(text)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It encodes u as NullUUID does.
+func (u NullBinaryUUID) MarshalJSON() ([]byte, error) {
+	return NullUUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalJSON.
+func (u *NullBinaryUUID) UnmarshalJSON(data []byte) error {
+	return (*NullUUID)(u).UnmarshalJSON(data)
+}
+
+// NullGUID is a NullUUID stored in the database as 16 bytes in the
+// layout of Windows GUID structure, as in SQL Server uniqueidentifier
+// columns, see LegacyGUID.
+type NullGUID NullUUID
+
+// Value implements the driver.Valuer interface.
+// It returns 16 bytes of UUID in GUID layout if valid, otherwise
+// it returns nil.
+func (u NullGUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return GUIDFromUUID(u.UUID).Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as NullUUID.Scan, except 16 bytes,
+// which are in GUID layout. Value is left intact if src can't
+// be scanned.
+func (u *NullGUID) Scan(src interface{}) error {
+	switch b := src.(type) {
+	case []byte:
+		if len(b) == Size {
+			u.UUID, u.Valid = guidBytesToUUID(b), true
+			return nil
+		}
+	case [Size]byte:
+		u.UUID, u.Valid = guidBytesToUUID(b[:]), true
+		return nil
+	}
+	return (*NullUUID)(u).Scan(src)
+}
+
+// Returns UUID converted from 16 bytes in GUID layout.
+func guidBytesToUUID(b []byte) UUID {
+	g, _ := GUIDFromBytes(b)
+	return g.UUID()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// It encodes u as NullUUID does.
+func (u NullGUID) MarshalText() ([]byte, error) {
+	return NullUUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalText.
+func (u *NullGUID) UnmarshalText(text []byte) error {
+	return (*NullUUID)(u).UnmarshalText(text)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It encodes u as NullUUID does.
+func (u NullGUID) MarshalJSON() ([]byte, error) {
+	return NullUUID(u).MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the same inputs as NullUUID.UnmarshalJSON.
+func (u *NullGUID) UnmarshalJSON(data []byte) error {
+	return (*NullUUID)(u).UnmarshalJSON(data)
 }
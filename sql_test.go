@@ -22,9 +22,13 @@
 package uuid
 
 import (
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
 )
 
 func TestValue(t *testing.T) {
@@ -52,6 +56,175 @@ func TestNullUUIDValueNil(t *testing.T) {
 	assert.Nil(t, val)
 }
 
+func TestNullCompactUUID(t *testing.T) {
+	u := NullCompactUUID{UUID: NamespaceDNS, Valid: true}
+
+	val, err := u.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8", val)
+
+	var u2 NullCompactUUID
+	require.NoError(t, u2.Scan(val))
+	assert.Equal(t, u, u2)
+
+	require.NoError(t, u2.Scan(nil))
+	assert.Equal(t, NullCompactUUID{}, u2)
+
+	val, err = u2.Value()
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestNullBinaryUUID(t *testing.T) {
+	u := NullBinaryUUID{UUID: NamespaceDNS, Valid: true}
+
+	val, err := u.Value()
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS.Bytes(), val)
+
+	var u2 NullBinaryUUID
+	require.NoError(t, u2.Scan(val))
+	assert.Equal(t, u, u2)
+
+	val, err = NullBinaryUUID{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func TestNullGUID(t *testing.T) {
+	u := NullGUID{UUID: NamespaceDNS, Valid: true}
+
+	val, err := u.Value()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x10, 0xb8, 0xa7, 0x6b, 0xad, 0x9d, 0xd1, 0x11, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, val)
+
+	var u2 NullGUID
+	require.NoError(t, u2.Scan(val))
+	assert.Equal(t, u, u2)
+
+	var arr [Size]byte
+	copy(arr[:], val.([]byte))
+	var u3 NullGUID
+	require.NoError(t, u3.Scan(arr))
+	assert.Equal(t, u, u3)
+
+	// Text is scanned as is.
+	var u4 NullGUID
+	require.NoError(t, u4.Scan(NamespaceDNS.String()))
+	assert.Equal(t, u, u4)
+
+	// Binary layout of NullUUID is big endian.
+	var u5 NullUUID
+	require.NoError(t, u5.Scan(val))
+	assert.NotEqual(t, NamespaceDNS, u5.UUID)
+
+	require.NoError(t, u2.Scan(nil))
+	assert.Equal(t, NullGUID{}, u2)
+
+	assert.Error(t, u.Scan("invalid"))
+	assert.Equal(t, NullGUID{UUID: NamespaceDNS, Valid: true}, u)
+}
+
+func TestNullUUIDWrappersJSON(t *testing.T) {
+	type record struct {
+		Compact NullCompactUUID
+		Binary  NullBinaryUUID
+		GUID    NullGUID
+	}
+	r := record{
+		Compact: NullCompactUUID{UUID: NamespaceDNS, Valid: true},
+		GUID:    NullGUID{UUID: NamespaceURL, Valid: true},
+	}
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Compact":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","Binary":null,"GUID":"6ba7b811-9dad-11d1-80b4-00c04fd430c8"}`, string(data))
+
+	r2 := record{Binary: NullBinaryUUID{UUID: NamespaceDNS, Valid: true}}
+	require.NoError(t, json.Unmarshal(data, &r2))
+	assert.Equal(t, r, r2)
+}
+
+func TestValueFormatValuer(t *testing.T) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	tests := []struct {
+		format   ValueFormat
+		expected interface{}
+	}{
+		{TextFormat, NamespaceDNS.String()},
+		{CompactFormat, NamespaceDNS.StringCompact()},
+		{BinaryFormat, NamespaceDNS.Bytes()},
+		{GUIDFormat, GUIDFromUUID(NamespaceDNS).Bytes()},
+	}
+	for _, tt := range tests {
+		val, err := tt.format.Valuer(u).Value()
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, val)
+
+		val, err = tt.format.Valuer(NullUUID{}).Value()
+		require.NoError(t, err)
+		assert.Nil(t, val)
+	}
+}
+
+func TestNullUUIDScanError(t *testing.T) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	assert.Error(t, u.Scan("invalid"))
+	assert.Equal(t, NullUUID{UUID: NamespaceDNS, Valid: true}, u)
+
+	u = NullUUID{}
+	assert.Error(t, u.Scan(42))
+	assert.False(t, u.Valid)
+}
+
+func TestBinaryUUID(t *testing.T) {
+	val, err := BinaryUUID(NamespaceDNS).Value()
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS.Bytes(), val)
+
+	var u BinaryUUID
+	require.NoError(t, u.Scan(val))
+	assert.Equal(t, BinaryUUID(NamespaceDNS), u)
+	assert.Equal(t, NamespaceDNS.String(), u.String())
+	assert.Error(t, u.Scan(42))
+}
+
+func TestBinaryUUIDJSON(t *testing.T) {
+	data, err := json.Marshal(BinaryUUID(NamespaceDNS))
+	require.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(data))
+
+	var u BinaryUUID
+	require.NoError(t, json.Unmarshal(data, &u))
+	assert.Equal(t, BinaryUUID(NamespaceDNS), u)
+	assert.Error(t, json.Unmarshal([]byte(`"invalid"`), &u))
+}
+
+func TestCompactUUID(t *testing.T) {
+	val, err := CompactUUID(NamespaceDNS).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8", val)
+
+	var u CompactUUID
+	require.NoError(t, u.Scan(val))
+	assert.Equal(t, CompactUUID(NamespaceDNS), u)
+	assert.Equal(t, NamespaceDNS.String(), u.String())
+
+	require.NoError(t, u.Scan(NamespaceURL.String()))
+	assert.Equal(t, CompactUUID(NamespaceURL), u)
+	assert.Error(t, u.Scan(42))
+}
+
+func TestCompactUUIDJSON(t *testing.T) {
+	data, err := json.Marshal(CompactUUID(NamespaceDNS))
+	require.NoError(t, err)
+	assert.Equal(t, `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, string(data))
+
+	var u CompactUUID
+	require.NoError(t, json.Unmarshal(data, &u))
+	assert.Equal(t, CompactUUID(NamespaceDNS), u)
+	assert.Error(t, json.Unmarshal([]byte(`"invalid"`), &u))
+}
+
 func TestScanBinary(t *testing.T) {
 	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
 	b1 := []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
@@ -97,6 +270,57 @@ func TestScanText(t *testing.T) {
 	assert.Error(t, err)
 }
 
+type guid struct {
+	s string
+}
+
+func (g guid) String() string {
+	return g.s
+}
+
+func TestScanArray(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	a1 := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	u1 := UUID{}
+	err := u1.Scan(a1)
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+}
+
+func TestScanStringPointer(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	s1 := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+	u1 := UUID{}
+	err := u1.Scan(&s1)
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+
+	var s2 *string
+	u2 := UUID{}
+	err = u2.Scan(s2)
+	assert.Error(t, err)
+}
+
+func TestScanStringer(t *testing.T) {
+	u := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	u1 := UUID{}
+	err := u1.Scan(guid{"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"})
+	require.NoError(t, err)
+	assert.Equal(t, u, u1)
+
+	u2 := UUID{}
+	err = u2.Scan(u)
+	require.NoError(t, err)
+	assert.Equal(t, u, u2)
+
+	u3 := UUID{}
+	err = u3.Scan(guid{"invalid"})
+	assert.Error(t, err)
+}
+
 func TestScanUnsupported(t *testing.T) {
 	u := UUID{}
 
@@ -130,3 +354,163 @@ func TestNullUUIDScanNil(t *testing.T) {
 	assert.False(t, u.Valid)
 	assert.Equal(t, Nil, u.UUID)
 }
+
+func TestNullUUIDEqual(t *testing.T) {
+	dns := NullUUID{UUID: NamespaceDNS, Valid: true}
+	url := NullUUID{UUID: NamespaceURL, Valid: true}
+
+	assert.True(t, NullUUID{}.Equal(NullUUID{}))
+	assert.True(t, NullUUID{}.Equal(NullUUID{UUID: NamespaceDNS}))
+	assert.True(t, dns.Equal(dns))
+	assert.False(t, dns.Equal(url))
+	assert.False(t, dns.Equal(NullUUID{}))
+	assert.False(t, NullUUID{}.Equal(dns))
+	assert.False(t, dns.Equal(NullUUID{UUID: NamespaceDNS}))
+}
+
+func TestNullUUIDText(t *testing.T) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	text, err := u.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", string(text))
+
+	u2 := NullUUID{}
+	require.NoError(t, u2.UnmarshalText(text))
+	assert.Equal(t, u, u2)
+
+	text, err = NullUUID{}.MarshalText()
+	require.NoError(t, err)
+	assert.Empty(t, text)
+
+	require.NoError(t, u2.UnmarshalText(text))
+	assert.Equal(t, NullUUID{}, u2)
+
+	assert.Error(t, u2.UnmarshalText([]byte("invalid")))
+	assert.False(t, u2.Valid)
+}
+
+func TestNullUUIDJSON(t *testing.T) {
+	type record struct {
+		ID     NullUUID
+		Parent NullUUID
+	}
+	r := record{ID: NullUUID{UUID: NamespaceDNS, Valid: true}}
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ID":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","Parent":null}`, string(data))
+
+	r2 := record{Parent: NullUUID{UUID: NamespaceURL, Valid: true}}
+	require.NoError(t, json.Unmarshal(data, &r2))
+	assert.Equal(t, r, r2)
+
+	tests := []struct {
+		input    string
+		expected NullUUID
+	}{
+		{`null`, NullUUID{}},
+		{`""`, NullUUID{}},
+		{`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`, NullUUID{UUID: NamespaceDNS, Valid: true}},
+		{`{"UUID":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","Valid":true}`, NullUUID{UUID: NamespaceDNS, Valid: true}},
+		{`{"UUID":"00000000-0000-0000-0000-000000000000","Valid":false}`, NullUUID{}},
+	}
+	for _, tt := range tests {
+		u := NullUUID{UUID: NamespaceURL, Valid: true}
+		require.NoError(t, json.Unmarshal([]byte(tt.input), &u), tt.input)
+		assert.Equal(t, tt.expected, u, tt.input)
+	}
+
+	for _, input := range []string{`"invalid"`, `42`, `{"UUID":"invalid"}`} {
+		var u NullUUID
+		assert.Error(t, json.Unmarshal([]byte(input), &u), input)
+	}
+}
+
+func TestNullUUIDBinary(t *testing.T) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	data, err := u.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS.Bytes(), data)
+
+	u2 := NullUUID{}
+	require.NoError(t, u2.UnmarshalBinary(data))
+	assert.Equal(t, u, u2)
+
+	data, err = NullUUID{}.MarshalBinary()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	require.NoError(t, u2.UnmarshalBinary(data))
+	assert.Equal(t, NullUUID{}, u2)
+
+	assert.Error(t, u2.UnmarshalBinary([]byte{1, 2, 3}))
+}
+
+func TestNullUUIDMapKey(t *testing.T) {
+	m := map[NullUUID]int{{UUID: NamespaceDNS, Valid: true}: 1, {}: 2}
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"6ba7b810-9dad-11d1-80b4-00c04fd430c8":1,"":2}`, string(data))
+
+	var m2 map[NullUUID]int
+	require.NoError(t, json.Unmarshal(data, &m2))
+	assert.Equal(t, m, m2)
+}
+
+func BenchmarkScan(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		src  interface{}
+	}{
+		{"Binary", NamespaceDNS.Bytes()},
+		{"String", NamespaceDNS.String()},
+		{"Text", []byte(NamespaceDNS.String())},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			u := UUID{}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = u.Scan(bm.src)
+			}
+		})
+	}
+}
+
+func BenchmarkNullUUIDScan(b *testing.B) {
+	for _, bm := range []struct {
+		name string
+		dest sql.Scanner
+		src  interface{}
+	}{
+		{"Null", &NullUUID{}, nil},
+		{"String", &NullUUID{}, NamespaceDNS.String()},
+		{"Binary", &NullBinaryUUID{}, NamespaceDNS.Bytes()},
+		{"GUID", &NullGUID{}, GUIDFromUUID(NamespaceDNS).Bytes()},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = bm.dest.Scan(bm.src)
+			}
+		})
+	}
+}
+
+func BenchmarkValue(b *testing.B) {
+	u := NullUUID{UUID: NamespaceDNS, Valid: true}
+	for _, bm := range []struct {
+		name string
+		val  driver.Valuer
+	}{
+		{"Text", u},
+		{"Compact", NullCompactUUID(u)},
+		{"Binary", NullBinaryUUID(u)},
+		{"GUID", NullGUID(u)},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = bm.val.Value()
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/satori/go.uuid/sqliteuuid
+
+go 1.23.0
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.33
+	github.com/satori/go.uuid v0.0.0
+)
+
+replace github.com/satori/go.uuid => ../
@@ -0,0 +1,58 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build cgo
+
+package sqliteuuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Register registers mattn/go-sqlite3 driver under name, providing
+// SQL functions of this package on every connection.
+func Register(name string) {
+	sql.Register(name, &sqlite3.SQLiteDriver{ConnectHook: RegisterFunctions})
+}
+
+// RegisterFunctions registers SQL functions of this package listed
+// in Functions on conn, for use in a custom sqlite3.SQLiteDriver.ConnectHook.
+func RegisterFunctions(conn *sqlite3.SQLiteConn) error {
+	for _, f := range Functions {
+		var impl interface{}
+		switch f.NArgs {
+		case 0:
+			impl = func() (driver.Value, error) { return f.Impl(nil) }
+		case 1:
+			impl = func(v interface{}) (driver.Value, error) { return f.Impl([]driver.Value{v}) }
+		default:
+			return fmt.Errorf("sqliteuuid: unsupported number of arguments %d of %s", f.NArgs, f.Name)
+		}
+		if err := conn.RegisterFunc(f.Name, impl, f.Deterministic); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+//go:build cgo
+
+package sqliteuuid
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func init() {
+	Register("sqlite3_uuid_test")
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3_uuid_test", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE items (id BLOB PRIMARY KEY, ref TEXT, parent)")
+	require.NoError(t, err)
+	return db
+}
+
+func TestColumnFormats(t *testing.T) {
+	db := openDB(t)
+	formats, err := ColumnFormats(context.Background(), db, "items")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uuid.ValueFormat{
+		"id":     uuid.BinaryFormat,
+		"ref":    uuid.TextFormat,
+		"parent": uuid.BinaryFormat,
+	}, formats)
+
+	_, err = ColumnFormats(context.Background(), db, "missing")
+	assert.Error(t, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	db := openDB(t)
+	ctx := context.Background()
+	formats, err := ColumnFormats(ctx, db, "items")
+	require.NoError(t, err)
+
+	id, ref := uuid.Must(uuid.NewV7()), uuid.NamespaceDNS
+	_, err = db.Exec("INSERT INTO items (id, ref, parent) VALUES (?, ?, ?)",
+		formats["id"].Valuer(uuid.NullUUID{UUID: id, Valid: true}),
+		formats["ref"].Valuer(uuid.NullUUID{UUID: ref, Valid: true}),
+		formats["parent"].Valuer(uuid.NullUUID{}))
+	require.NoError(t, err)
+
+	var idType, refType string
+	require.NoError(t, db.QueryRow("SELECT typeof(id), typeof(ref) FROM items").Scan(&idType, &refType))
+	assert.Equal(t, "blob", idType)
+	assert.Equal(t, "text", refType)
+
+	var gotID, gotRef uuid.UUID
+	var parent uuid.NullUUID
+	require.NoError(t, db.QueryRow("SELECT id, ref, parent FROM items").Scan(&gotID, &gotRef, &parent))
+	assert.Equal(t, id, gotID)
+	assert.Equal(t, ref, gotRef)
+	assert.False(t, parent.Valid)
+
+	// Lookup by text converted inside the query.
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM items WHERE id = uuid_blob(?)", id.String()).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestSQLFunctions(t *testing.T) {
+	db := openDB(t)
+
+	var s string
+	var b []byte
+	require.NoError(t, db.QueryRow("SELECT uuid_str(uuid_blob(?)), uuid_blob(?)",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", uuid.NamespaceDNS.String()).Scan(&s, &b))
+	assert.Equal(t, uuid.NamespaceDNS.String(), s)
+	assert.Equal(t, uuid.NamespaceDNS.Bytes(), b)
+
+	var invalid sql.NullString
+	require.NoError(t, db.QueryRow("SELECT uuid_str('invalid')").Scan(&invalid))
+	assert.False(t, invalid.Valid)
+
+	var v4, v7 uuid.UUID
+	require.NoError(t, db.QueryRow("SELECT uuid(), uuid_v7()").Scan(&v4, &v7))
+	assert.Equal(t, uuid.V4, v4.Version())
+	assert.Equal(t, uuid.V7, v7.Version())
+}
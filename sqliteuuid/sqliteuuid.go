@@ -0,0 +1,192 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package sqliteuuid helps storing UUIDs in SQLite, which has no native
+// UUID type. Two conventions are common: canonical strings in TEXT
+// columns and 16 raw bytes in BLOB columns. Scanning handles both, as
+// uuid.UUID.Scan accepts either.
+//
+// Written values are not converted automatically: SQLite doesn't report
+// which column a statement parameter ends up in, so no driver can pick
+// representation by declared column type. FormatFor and ColumnFormats
+// tell the representation matching a column, and ValueFor or
+// uuid.ValueFormat.Valuer produce it:
+//
+//	formats, err := sqliteuuid.ColumnFormats(ctx, db, "users")
+//	...
+//	_, err = db.ExecContext(ctx, "INSERT INTO users (id) VALUES (?)",
+//		formats["id"].Valuer(uuid.NullUUID{UUID: id, Valid: true}))
+//
+// SQL functions uuid(), uuid_str(X) and uuid_blob(X), compatible with
+// the uuid extension of SQLite, plus uuid_v7(), convert UUIDs inside
+// queries. With mattn/go-sqlite3, register a driver providing them:
+//
+//	sqliteuuid.Register("sqlite3_uuid")
+//	db, err := sql.Open("sqlite3_uuid", "file.db")
+//
+// With other drivers, such as modernc.org/sqlite, register Functions
+// on startup, e.g.:
+//
+//	for _, f := range sqliteuuid.Functions {
+//		impl := f.Impl
+//		sqlite.MustRegisterScalarFunction(f.Name, f.NArgs,
+//			func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+//				return impl(args)
+//			})
+//	}
+package sqliteuuid
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// FormatFor returns representation of UUID matching declared type
+// of a column, following the rules of SQLite type affinity: 16 bytes for
+// columns of BLOB affinity, i.e. declared as BLOB or without a type,
+// otherwise canonical string.
+func FormatFor(declType string) uuid.ValueFormat {
+	t := strings.ToUpper(declType)
+	switch {
+	case strings.Contains(t, "INT"), strings.Contains(t, "CHAR"),
+		strings.Contains(t, "CLOB"), strings.Contains(t, "TEXT"):
+		return uuid.TextFormat
+	case t == "", strings.Contains(t, "BLOB"):
+		return uuid.BinaryFormat
+	default:
+		return uuid.TextFormat
+	}
+}
+
+// ValueFor returns u in representation matching declared type
+// of a column, see FormatFor.
+func ValueFor(declType string, u uuid.UUID) driver.Value {
+	if FormatFor(declType) == uuid.BinaryFormat {
+		return u.Bytes()
+	}
+	return u.String()
+}
+
+// Queryer is implemented by *sql.DB, *sql.Conn and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ColumnFormats returns representations of UUID matching declared types
+// of all columns of table, see FormatFor, keyed by column names.
+func ColumnFormats(ctx context.Context, q Queryer, table string) (map[string]uuid.ValueFormat, error) {
+	rows, err := q.QueryContext(ctx, "SELECT name, type FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]uuid.ValueFormat)
+	for rows.Next() {
+		var name, declType string
+		if err := rows.Scan(&name, &declType); err != nil {
+			return nil, err
+		}
+		res[name] = FormatFor(declType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("sqliteuuid: no such table: %s", table)
+	}
+	return res, nil
+}
+
+// Returns UUID given as SQL value of any representation, or false
+// if it's NULL or not a UUID.
+func parse(v driver.Value) (uuid.UUID, bool) {
+	if v == nil {
+		return uuid.Nil, false
+	}
+	var u uuid.UUID
+	return u, u.Scan(v) == nil
+}
+
+// Function describes SQL function of this package for registration
+// with drivers other than mattn/go-sqlite3.
+type Function struct {
+	// Name is name of the function in SQL.
+	Name string
+	// NArgs is number of arguments of the function.
+	NArgs int32
+	// Deterministic is true if the function always returns the same
+	// result for the same arguments.
+	Deterministic bool
+	// Impl implements the function.
+	Impl func(args []driver.Value) (driver.Value, error)
+}
+
+// Functions lists SQL functions of this package.
+var Functions = []Function{
+	{"uuid", 0, false, func([]driver.Value) (driver.Value, error) { return New() }},
+	{"uuid_v7", 0, false, func([]driver.Value) (driver.Value, error) { return NewV7() }},
+	{"uuid_str", 1, true, func(args []driver.Value) (driver.Value, error) { return Str(args[0]), nil }},
+	{"uuid_blob", 1, true, func(args []driver.Value) (driver.Value, error) { return Blob(args[0]), nil }},
+}
+
+// Str implements SQL function uuid_str(X), returning canonical string
+// of UUID given as 16 bytes or text, or NULL if X isn't a UUID.
+func Str(v driver.Value) driver.Value {
+	if u, ok := parse(v); ok {
+		return u.String()
+	}
+	return nil
+}
+
+// Blob implements SQL function uuid_blob(X), returning 16 bytes
+// of UUID given as 16 bytes or text, or NULL if X isn't a UUID.
+func Blob(v driver.Value) driver.Value {
+	if u, ok := parse(v); ok {
+		return u.Bytes()
+	}
+	return nil
+}
+
+// New implements SQL function uuid(), returning canonical string
+// of a new version 4 UUID.
+func New() (driver.Value, error) {
+	u, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	return u.String(), nil
+}
+
+// NewV7 implements SQL function uuid_v7(), returning canonical string
+// of a new version 7 UUID.
+func NewV7() (driver.Value, error) {
+	u, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,92 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package sqliteuuid
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestFormatFor(t *testing.T) {
+	tests := []struct {
+		declType string
+		expected uuid.ValueFormat
+	}{
+		{"", uuid.BinaryFormat},
+		{"BLOB", uuid.BinaryFormat},
+		{"blob", uuid.BinaryFormat},
+		{"TEXT", uuid.TextFormat},
+		{"VARCHAR(36)", uuid.TextFormat},
+		{"CHARACTER(36)", uuid.TextFormat},
+		{"CLOB", uuid.TextFormat},
+		{"UUID", uuid.TextFormat},
+		{"INTEGER", uuid.TextFormat},
+		{"BLOBINT", uuid.TextFormat},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, FormatFor(tt.declType), tt.declType)
+	}
+
+	assert.Equal(t, uuid.NamespaceDNS.Bytes(), ValueFor("BLOB", uuid.NamespaceDNS))
+	assert.Equal(t, uuid.NamespaceDNS.String(), ValueFor("TEXT", uuid.NamespaceDNS))
+}
+
+func TestFunctions(t *testing.T) {
+	for _, v := range []interface{}{uuid.NamespaceDNS.Bytes(), uuid.NamespaceDNS.String(), []byte("{6ba7b810-9dad-11d1-80b4-00c04fd430c8}")} {
+		assert.Equal(t, uuid.NamespaceDNS.String(), Str(v))
+		assert.Equal(t, uuid.NamespaceDNS.Bytes(), Blob(v))
+	}
+	for _, v := range []interface{}{nil, "invalid", []byte{1, 2, 3}, int64(42)} {
+		assert.Nil(t, Str(v))
+		assert.Nil(t, Blob(v))
+	}
+
+	v, err := New()
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.V4, uuid.Must(uuid.FromString(v.(string))).Version())
+
+	v, err = NewV7()
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.V7, uuid.Must(uuid.FromString(v.(string))).Version())
+}
+
+func TestFunctionsList(t *testing.T) {
+	arg := uuid.NamespaceDNS.String()
+	for _, f := range Functions {
+		args := make([]driver.Value, f.NArgs)
+		for i := range args {
+			args[i] = arg
+		}
+		v, err := f.Impl(args)
+		assert.NoError(t, err, f.Name)
+
+		u, err := uuid.FromString(Str(v).(string))
+		assert.NoError(t, err, f.Name)
+		if f.Deterministic {
+			assert.Equal(t, uuid.NamespaceDNS, u, f.Name)
+		}
+	}
+}
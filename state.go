@@ -0,0 +1,116 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"math/rand/v2"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+// GeneratorState is a snapshot of generator state, meant for debug
+// endpoints and detection of clock anomalies.
+type GeneratorState struct {
+	// LastTime is timestamp of the last time-based (version 1, 2 or 6)
+	// UUID, or zero time if none has been generated yet.
+	LastTime time.Time
+	// ClockSequence is clock sequence of the last time-based UUID.
+	// With clock shards it is the one of the shard which has generated
+	// the latest timestamp.
+	ClockSequence uint16
+	// Node is node ID of time-based UUIDs, or nil if it hasn't been
+	// determined yet.
+	Node net.HardwareAddr
+	// Generated holds numbers of UUIDs generated so far, indexed by
+	// version. Name-based UUIDs (version 3 and 5) aren't counted.
+	Generated [V8 + 1]uint64
+	// ClockRegressions is the number of times the clock was found to have
+	// moved backwards when generating time-based UUIDs.
+	ClockRegressions uint64
+}
+
+// StateReporter provides interface for taking snapshots of generator
+// state. Generators returned by NewGenerator implement it.
+type StateReporter interface {
+	State() GeneratorState
+}
+
+// GlobalState returns snapshot of state of generator used by
+// package-level functions.
+func GlobalState() GeneratorState {
+	return global().State()
+}
+
+// State returns snapshot of generator state. It is safe to call
+// concurrently with generation of UUIDs.
+func (g *rfc4122Generator) State() GeneratorState {
+	var s GeneratorState
+	for i := range g.counters {
+		for v := range s.Generated {
+			s.Generated[v] += g.counters[i].generated[v].Load()
+		}
+	}
+
+	s.ClockRegressions = g.clockRegressions.Load()
+
+	if g.hardwareAddrReady.Load() {
+		s.Node = append(net.HardwareAddr(nil), g.hardwareAddr[:]...)
+	}
+
+	if g.clockReady.Load() {
+		var lastTime uint64
+		for i := range g.clockShards {
+			shard := &g.clockShards[i]
+			shard.mu.Lock()
+			if i == 0 || shard.lastTime > lastTime {
+				lastTime = shard.lastTime
+				s.ClockSequence = shard.clockSequence
+			}
+			shard.mu.Unlock()
+		}
+		if lastTime != 0 {
+			s.LastTime = epochTime(lastTime)
+		}
+	}
+	return s
+}
+
+// Number of stripes of counters of generated UUIDs.
+const counterStripes = maxClockShards
+
+// counterStripe holds numbers of UUIDs generated by goroutines sharing
+// it, indexed by version. Counting into stripes spread across calls
+// keeps concurrent generation of UUIDs from contending on one counter.
+type counterStripe struct {
+	generated [V8 + 1]atomic.Uint64
+
+	// Prevents false sharing of adjacent stripes.
+	_ [56]byte
+}
+
+// Adds n to the number of generated UUIDs of version v. The stripe is
+// picked by the runtime's per-thread random number generator, which is
+// cheaper than any shared state and doesn't contend between threads.
+func (g *rfc4122Generator) countGenerated(v byte, n uint64) {
+	g.counters[rand.Uint32()%counterStripes].generated[v].Add(n)
+}
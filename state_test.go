@@ -0,0 +1,128 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"net"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestState(t *testing.T) {
+	ts := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	hwAddr := net.HardwareAddr{0x00, 0x1b, 0x21, 0x3a, 0x4f, 0x10}
+	g := newClockGenerator(ClockRegressionIncrement, ts)
+	g.hwAddrFunc = func() (net.HardwareAddr, error) { return hwAddr, nil }
+
+	s := g.State()
+	assert.True(t, s.LastTime.IsZero())
+	assert.Nil(t, s.Node)
+	assert.Equal(t, [V8 + 1]uint64{}, s.Generated)
+
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+	_, err = g.NewV1()
+	require.NoError(t, err)
+	_, err = g.NewV2(DomainOrg)
+	require.NoError(t, err)
+	_, err = g.NewV4()
+	require.NoError(t, err)
+	_, err = g.NewV7()
+	require.NoError(t, err)
+	_, err = g.ReserveV7(3)
+	require.NoError(t, err)
+	g.NewV5(NamespaceDNS, "example.com")
+
+	s = g.State()
+	assert.True(t, ts.Equal(s.LastTime), s.LastTime)
+	assert.Equal(t, hwAddr, s.Node)
+	assert.Equal(t, uint64(2), s.Generated[V1])
+	assert.Equal(t, uint64(1), s.Generated[V2])
+	assert.Equal(t, uint64(1), s.Generated[V4])
+	assert.Equal(t, uint64(0), s.Generated[V5])
+	assert.Equal(t, uint64(4), s.Generated[V7])
+
+	// Clock sequence was incremented twice for the same timestamp,
+	// its top bits are overwritten by variant in UUID.
+	expected := g.nextClockSequence(g.nextClockSequence(clockSequenceOf(u1)))
+	assert.Equal(t, expected&0x3fff, s.ClockSequence&0x3fff)
+}
+
+func TestStateShards(t *testing.T) {
+	var sr StateReporter = NewGenerator(WithClockShards(4)).(*rfc4122Generator)
+	g := sr.(*rfc4122Generator)
+	for i := 0; i < 100; i++ {
+		_, err := g.NewV6()
+		require.NoError(t, err)
+	}
+	s := sr.State()
+	assert.WithinDuration(t, time.Now(), s.LastTime, time.Minute)
+	assert.Equal(t, uint64(100), s.Generated[V6])
+}
+
+func TestStateConcurrentCounts(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	g := newRFC4122Generator()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				_, err := g.NewV4()
+				assert.NoError(t, err)
+				_, err = g.NewV7()
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	s := g.State()
+	assert.Equal(t, uint64(8000), s.Generated[V4])
+	assert.Equal(t, uint64(8000), s.Generated[V7])
+}
+
+func TestGlobalState(t *testing.T) {
+	before := GlobalState().Generated[V4]
+	_, err := NewV4()
+	require.NoError(t, err)
+	assert.Less(t, before, GlobalState().Generated[V4])
+}
+
+func clockSequenceOf(u UUID) uint16 {
+	return uint16(u[8])<<8 | uint16(u[9])
+}
+
+func BenchmarkCountGenerated(b *testing.B) {
+	g := newRFC4122Generator()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.countGenerated(V4, 1)
+		}
+	})
+}
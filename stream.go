@@ -0,0 +1,159 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"iter"
+)
+
+// DecodeAll returns an iterator over UUIDs read from r, where values are
+// separated by sep or by newlines. Surrounding whitespace is ignored and
+// empty values are skipped. Each value is expected in a form accepted by
+// UnmarshalText. A malformed value yields an error and iteration goes on
+// with the next value, while a read error yields an error and stops
+// the iteration.
+func DecodeAll(r io.Reader, sep byte) iter.Seq2[UUID, error] {
+	return func(yield func(UUID, error) bool) {
+		scanner := bufio.NewScanner(r)
+		scanner.Split(splitOn(sep))
+		for scanner.Scan() {
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+			u := UUID{}
+			if err := u.UnmarshalText(text); err != nil {
+				if !yield(Nil, err) {
+					return
+				}
+				continue
+			}
+			if !yield(u, nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(Nil, err)
+		}
+	}
+}
+
+// Returns bufio.SplitFunc splitting input on sep and newlines.
+func splitOn(sep byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		for i, c := range data {
+			if c == sep || c == '\n' {
+				return i + 1, data[:i], nil
+			}
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
+
+// ReadUUID reads 16 raw bytes of UUID from r. It returns io.EOF only
+// if no bytes were read, and io.ErrUnexpectedEOF if r ended within
+// the UUID.
+func ReadUUID(r io.Reader) (UUID, error) {
+	u := UUID{}
+	if _, err := io.ReadFull(r, u[:]); err != nil {
+		return Nil, err
+	}
+	return u, nil
+}
+
+// WriteTo implements the io.WriterTo interface.
+// It writes 16 raw bytes of UUID to w.
+func (u UUID) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(u[:])
+	return int64(n), err
+}
+
+// ReadFrom implements the io.ReaderFrom interface. Unlike most readers
+// from, it reads exactly 16 raw bytes of UUID from r rather than reading
+// until EOF, so that UUIDs can be read one after another from a stream.
+// If r ends before all the bytes are read, including when r is empty,
+// io.ErrUnexpectedEOF is returned and u is left intact.
+func (u *UUID) ReadFrom(r io.Reader) (int64, error) {
+	var buf UUID
+	n, err := io.ReadFull(r, buf[:])
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return int64(n), err
+	}
+	*u = buf
+	return int64(n), nil
+}
+
+// BinaryDecoder reads successive UUIDs from a stream of their raw
+// bytes, as written by EncodeSlice, e.g. from a file snapshot or
+// a network frame. It buffers reads from the underlying reader.
+type BinaryDecoder struct {
+	r *bufio.Reader
+}
+
+// NewBinaryDecoder returns BinaryDecoder reading from r.
+func NewBinaryDecoder(r io.Reader) *BinaryDecoder {
+	return &BinaryDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads next UUID from the stream into u. Same behavior as
+// ReadUUID: at the end of the stream it returns io.EOF, or
+// io.ErrUnexpectedEOF if the stream ended within a UUID. On error
+// u is left intact.
+func (d *BinaryDecoder) Decode(u *UUID) error {
+	var buf UUID
+	if _, err := io.ReadFull(d.r, buf[:]); err != nil {
+		return err
+	}
+	*u = buf
+	return nil
+}
+
+// All returns an iterator over UUIDs remaining in the stream. The end
+// of the stream stops the iteration, while any other error, including
+// a truncated UUID, is yielded and stops the iteration.
+func (d *BinaryDecoder) All() iter.Seq2[UUID, error] {
+	return func(yield func(UUID, error) bool) {
+		for {
+			u := UUID{}
+			err := d.Decode(&u)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Nil, err)
+				return
+			}
+			if !yield(u, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestDecodeAll(t *testing.T) {
+	input := "6ba7b810-9dad-11d1-80b4-00c04fd430c8,6ba7b811-9dad-11d1-80b4-00c04fd430c8\r\n" +
+		" {6ba7b812-9dad-11d1-80b4-00c04fd430c8} \n\n"
+
+	var ids []UUID
+	for u, err := range DecodeAll(strings.NewReader(input), ',') {
+		require.NoError(t, err)
+		ids = append(ids, u)
+	}
+	assert.Equal(t, []UUID{NamespaceDNS, NamespaceURL, NamespaceOID}, ids)
+}
+
+func TestDecodeAllInvalid(t *testing.T) {
+	input := "6ba7b810-9dad-11d1-80b4-00c04fd430c8\ninvalid\n6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+
+	var ids []UUID
+	var errs int
+	for u, err := range DecodeAll(strings.NewReader(input), ',') {
+		if err != nil {
+			errs++
+			continue
+		}
+		ids = append(ids, u)
+	}
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, []UUID{NamespaceDNS, NamespaceURL}, ids)
+}
+
+func TestDecodeAllBreak(t *testing.T) {
+	input := "6ba7b810-9dad-11d1-80b4-00c04fd430c8\n6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+
+	n := 0
+	for range DecodeAll(strings.NewReader(input), ',') {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestDecodeAllReadError(t *testing.T) {
+	r := iotest.ErrReader(iotest.ErrTimeout)
+
+	var errs []error
+	for _, err := range DecodeAll(r, ',') {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], iotest.ErrTimeout)
+}
+
+func BenchmarkDecodeAll(b *testing.B) {
+	var sb strings.Builder
+	for i := 0; i < 1000; i++ {
+		sb.WriteString(MustNewV4().String())
+		sb.WriteByte('\n')
+	}
+	input := sb.String()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range DecodeAll(strings.NewReader(input), ',') {
+		}
+	}
+}
+
+func TestReadUUID(t *testing.T) {
+	r := bytes.NewReader(EncodeSlice(nil, []UUID{NamespaceDNS, NamespaceURL}))
+	u, err := ReadUUID(r)
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceDNS, u)
+	u, err = ReadUUID(iotest.OneByteReader(r))
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceURL, u)
+	_, err = ReadUUID(r)
+	assert.Equal(t, io.EOF, err)
+
+	_, err = ReadUUID(bytes.NewReader(NamespaceDNS[:10]))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestBinaryDecoder(t *testing.T) {
+	ids := []UUID{NamespaceDNS, NamespaceURL, NamespaceOID}
+	data := EncodeSlice(nil, ids)
+
+	d := NewBinaryDecoder(iotest.HalfReader(bytes.NewReader(data)))
+	var u UUID
+	require.NoError(t, d.Decode(&u))
+	assert.Equal(t, NamespaceDNS, u)
+
+	var rest []UUID
+	for u, err := range d.All() {
+		require.NoError(t, err)
+		rest = append(rest, u)
+	}
+	assert.Equal(t, ids[1:], rest)
+	assert.Equal(t, io.EOF, d.Decode(&u))
+}
+
+func TestBinaryDecoderTruncated(t *testing.T) {
+	data := EncodeSlice(nil, []UUID{NamespaceDNS, NamespaceURL})
+	d := NewBinaryDecoder(bytes.NewReader(data[:Size+5]))
+
+	var ids []UUID
+	var errs []error
+	for u, err := range d.All() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ids = append(ids, u)
+	}
+	assert.Equal(t, []UUID{NamespaceDNS}, ids)
+	assert.Equal(t, []error{io.ErrUnexpectedEOF}, errs)
+
+	// Truncated UUID isn't stored.
+	d = NewBinaryDecoder(bytes.NewReader(data[Size : Size+5]))
+	u := NamespaceOID
+	assert.Equal(t, io.ErrUnexpectedEOF, d.Decode(&u))
+	assert.Equal(t, NamespaceOID, u)
+}
+
+func TestBinaryDecoderReadError(t *testing.T) {
+	d := NewBinaryDecoder(iotest.ErrReader(io.ErrClosedPipe))
+	var u UUID
+	assert.Equal(t, io.ErrClosedPipe, d.Decode(&u))
+}
+
+func TestWriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := NamespaceDNS.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(Size), n)
+	n, err = io.Copy(&buf, bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	assert.Equal(t, NamespaceDNS.Bytes(), buf.Bytes())
+
+	_, err = NamespaceDNS.WriteTo(failingWriter{})
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
+func TestReadFrom(t *testing.T) {
+	r := bytes.NewReader(EncodeSlice(nil, []UUID{NamespaceDNS, NamespaceURL}))
+
+	var u UUID
+	n, err := u.ReadFrom(r)
+	require.NoError(t, err)
+	assert.Equal(t, int64(Size), n)
+	assert.Equal(t, NamespaceDNS, u)
+
+	n, err = u.ReadFrom(iotest.OneByteReader(r))
+	require.NoError(t, err)
+	assert.Equal(t, int64(Size), n)
+	assert.Equal(t, NamespaceURL, u)
+
+	_, err = u.ReadFrom(r)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	n, err = u.ReadFrom(bytes.NewReader(NamespaceOID[:3]))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, NamespaceURL, u)
+}
+
+// Writer failing every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
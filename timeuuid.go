@@ -0,0 +1,286 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+)
+
+// CompareTimeUUID compares two time-based (version 1) UUIDs using the
+// ordering Cassandra applies to its timeuuid type. UUIDs are compared by
+// their 60-bit timestamp first; ties are broken by comparing the clock
+// sequence and node bytes as signed bytes. The result is -1 if a < b,
+// 0 if a == b and +1 if a > b.
+func CompareTimeUUID(a, b UUID) int {
+	ta, tb := timestampV1(a), timestampV1(b)
+	switch {
+	case ta < tb:
+		return -1
+	case ta > tb:
+		return 1
+	}
+
+	for i := 8; i < Size; i++ {
+		ba, bb := int8(a[i]), int8(b[i])
+		switch {
+		case ba < bb:
+			return -1
+		case ba > bb:
+			return 1
+		}
+	}
+	return 0
+}
+
+// TimestampFromV7 returns time stored in version 7 UUID.
+// It will return error if UUID isn't of version 7.
+func TimestampFromV7(u UUID) (time.Time, error) {
+	return TimestampFromV7WithEpoch(u, time.Unix(0, 0))
+}
+
+// TimestampFromV7WithEpoch returns time stored in version 7 UUID generated
+// with WithV7Epoch option. It will return error if UUID isn't of version 7.
+func TimestampFromV7WithEpoch(u UUID, epoch time.Time) (time.Time, error) {
+	if u.Version() != V7 {
+		return time.Time{}, fmt.Errorf("uuid: expected version %d, got version %d", V7, u.Version())
+	}
+	// Milliseconds of 48 bits overflow time.Duration, unlike seconds.
+	ms := int64(getUint48(u[:6]))
+	t := time.Unix(epoch.Unix()+ms/1000, int64(epoch.Nanosecond())+ms%1000*1e6)
+	return t.In(epoch.Location()), nil
+}
+
+// NewV1At returns version 1 UUID holding timestamp t, the low 14 bits
+// of clockSeq and node, without touching state of any generator.
+// Given the same arguments it always returns the same UUID, which lets
+// replay and migration tools regenerate historical UUIDs bit for bit.
+// Timestamp is truncated to 100 nanoseconds resolution. It will return
+// error if t is out of range of 60-bit timestamp, i.e. before
+// 1582-10-15 or after 5236-03-31.
+func NewV1At(t time.Time, clockSeq uint16, node [6]byte) (UUID, error) {
+	ts, err := timestampAt(t)
+	if err != nil {
+		return Nil, err
+	}
+	u := UUID{}
+	putTimeV1(&u, ts)
+	binary.BigEndian.PutUint16(u[8:], clockSeq)
+	copy(u[10:], node[:])
+	return finalizeUUID(u, V1), nil
+}
+
+// NewV6At returns version 6 UUID holding timestamp t, the low 14 bits
+// of clockSeq and node. Same behavior as NewV1At.
+func NewV6At(t time.Time, clockSeq uint16, node [6]byte) (UUID, error) {
+	ts, err := timestampAt(t)
+	if err != nil {
+		return Nil, err
+	}
+	u := UUID{}
+	putTimeV6(&u, ts)
+	binary.BigEndian.PutUint16(u[8:], clockSeq)
+	copy(u[10:], node[:])
+	return finalizeUUID(u, V6), nil
+}
+
+// Range of seconds since Unix epoch representable by 60-bit timestamp
+// of time-based UUIDs.
+const (
+	minTimestampSec = -epochStart / 10000000
+	maxTimestampSec = (1<<60 - 1 - epochStart) / 10000000
+)
+
+// Returns 60-bit timestamp of t in 100 nanoseconds intervals since
+// the epoch of time-based UUIDs, or error if t is out of its range.
+// Unlike t.UnixNano, it doesn't overflow outside years 1678 to 2262.
+func timestampAt(t time.Time) (uint64, error) {
+	if sec := t.Unix(); sec >= minTimestampSec && sec <= maxTimestampSec {
+		ts := sec*1e7 + int64(t.Nanosecond()/100) + epochStart
+		if ts >= 0 && ts < 1<<60 {
+			return uint64(ts), nil
+		}
+	}
+	return 0, fmt.Errorf("uuid: time %s out of range of time-based UUIDs", t)
+}
+
+// Returns time of timestamp given in 100ns intervals since the epoch
+// of time-based UUIDs. Unlike nanoseconds since Unix epoch, seconds
+// don't overflow int64 for any 60-bit timestamp.
+func epochTime(timestamp uint64) time.Time {
+	d := int64(timestamp) - epochStart
+	return time.Unix(d/1e7, d%1e7*100)
+}
+
+// Puts 60-bit timestamp into fields of version 1 UUID.
+func putTimeV1(u *UUID, ts uint64) {
+	binary.BigEndian.PutUint32(u[0:], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:], uint16(ts>>48))
+}
+
+// Puts 60-bit timestamp into fields of version 6 UUID,
+// most significant bits first.
+func putTimeV6(u *UUID, ts uint64) {
+	binary.BigEndian.PutUint32(u[0:], uint32(ts>>28))    // time_high
+	binary.BigEndian.PutUint16(u[4:], uint16(ts>>12))    // time_mid
+	binary.BigEndian.PutUint16(u[6:], uint16(ts&0x0fff)) // time_low
+}
+
+// TruncateToTimeBucket returns UUID of the same version holding the
+// timestamp of u rounded down to a multiple of d, with all the other
+// bits set to zero. UUIDs falling into the same time bucket map to the
+// same value, which makes it a deterministic partition key.
+// Version 1 and version 7 UUIDs are supported.
+func (u UUID) TruncateToTimeBucket(d time.Duration) (UUID, error) {
+	_, t, err := timeBucket(u, d)
+	if err != nil {
+		return Nil, err
+	}
+
+	res := UUID{}
+	switch u.Version() {
+	case V1:
+		putTimeV1(&res, epochStart+uint64(t.Unix()*1e7+int64(t.Nanosecond()/100)))
+	case V7:
+		putUint48(res[:6], uint64(t.UnixMilli()))
+	}
+	return finalizeUUID(res, u.Version()), nil
+}
+
+// BucketKey returns index of the time bucket of length d, counted from
+// Unix epoch, which the timestamp of u falls into.
+// Version 1 and version 7 UUIDs are supported.
+func BucketKey(u UUID, d time.Duration) (int64, error) {
+	key, _, err := timeBucket(u, d)
+	return key, err
+}
+
+// Returns index of the time bucket of length d, counted from Unix epoch,
+// which the timestamp of u falls into, and the time the bucket starts at.
+// Timestamps of version 1 UUIDs reach year 5236 and those of version 7
+// UUIDs year 10889, beyond the range of int64 nanoseconds, so the bucket
+// is computed with 128-bit arithmetic.
+func timeBucket(u UUID, d time.Duration) (int64, time.Time, error) {
+	if d <= 0 {
+		return 0, time.Time{}, fmt.Errorf("uuid: non-positive time bucket duration %s", d)
+	}
+
+	// Timestamp in units of unit nanoseconds since Unix epoch.
+	var ts int64
+	var unit uint64
+	switch u.Version() {
+	case V1:
+		ts, unit = int64(timestampV1(u)-epochStart), 100
+	case V7:
+		ts, unit = int64(getUint48(u[:6])), uint64(time.Millisecond)
+	default:
+		return 0, time.Time{}, fmt.Errorf("uuid: expected version %d or %d, got version %d", V1, V7, u.Version())
+	}
+
+	// Timestamps of version 1 UUIDs before Unix epoch are negative and
+	// rounded away from zero, so that buckets are rounded down.
+	neg := ts < 0
+	abs := uint64(ts)
+	if neg {
+		abs = -abs
+	}
+	hi, lo := bits.Mul64(abs, unit)
+	if hi >= uint64(d) {
+		return 0, time.Time{}, fmt.Errorf("uuid: time bucket duration %s too short for timestamp", d)
+	}
+	n, rem := bits.Div64(hi, lo, uint64(d))
+	if neg && rem != 0 {
+		n++
+	}
+	if n > math.MaxInt64 {
+		return 0, time.Time{}, fmt.Errorf("uuid: time bucket duration %s too short for timestamp", d)
+	}
+
+	hi, lo = bits.Mul64(n, uint64(d))
+	sec, nsec := bits.Div64(hi, lo, uint64(time.Second))
+	if neg {
+		return -int64(n), time.Unix(-int64(sec), -int64(nsec)), nil
+	}
+	return int64(n), time.Unix(int64(sec), int64(nsec)), nil
+}
+
+// Age returns time elapsed since the timestamp stored in u, which is
+// negative for timestamps in the future.
+// Version 1, version 6 and version 7 UUIDs are supported.
+func Age(u UUID) (time.Duration, error) {
+	t, err := timeOf(u)
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(t), nil
+}
+
+// IsOlderThan returns true if the timestamp stored in u is more than d
+// in the past, e.g. to enforce TTL of one-time tokens or idempotency keys.
+// Version 1, version 6 and version 7 UUIDs are supported.
+func IsOlderThan(u UUID, d time.Duration) (bool, error) {
+	age, err := Age(u)
+	if err != nil {
+		return false, err
+	}
+	return age > d, nil
+}
+
+// Returns time stored in time-based UUID.
+func timeOf(u UUID) (time.Time, error) {
+	switch u.Version() {
+	case V1:
+		return epochTime(timestampV1(u)), nil
+	case V6:
+		return epochTime(timestampV6(u)), nil
+	case V7:
+		return TimestampFromV7(u)
+	default:
+		return time.Time{}, fmt.Errorf("uuid: expected version %d, %d or %d, got version %d", V1, V6, V7, u.Version())
+	}
+}
+
+// Returns 48-bit big-endian unsigned integer stored in b.
+func getUint48(b []byte) uint64 {
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+}
+
+// Returns 60-bit timestamp stored in version 1 UUID.
+func timestampV1(u UUID) uint64 {
+	low := uint64(binary.BigEndian.Uint32(u[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+	high := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	return high<<48 | mid<<32 | low
+}
+
+// Returns 60-bit timestamp stored in version 6 UUID.
+func timestampV6(u UUID) uint64 {
+	high := uint64(binary.BigEndian.Uint32(u[0:4]))
+	mid := uint64(binary.BigEndian.Uint16(u[4:6]))
+	low := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	return high<<28 | mid<<12 | low
+}
@@ -0,0 +1,354 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+	"github.com/satori/go.uuid/internal/vectors"
+)
+
+func TestCompareTimeUUID(t *testing.T) {
+	now := time.Now()
+	g := &rfc4122Generator{
+		epochFunc:  func() time.Time { return now },
+		hwAddrFunc: defaultHWAddrFunc,
+		rand:       rand.Reader,
+	}
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+
+	now = now.Add(time.Second)
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+
+	assert.Equal(t, -1, CompareTimeUUID(u1, u2))
+	assert.Equal(t, 1, CompareTimeUUID(u2, u1))
+	assert.Equal(t, 0, CompareTimeUUID(u1, u1))
+
+	// Same timestamp, differing clock sequence compared as signed bytes.
+	u3, u4 := u1, u1
+	u3[8], u4[8] = 0x80, 0x7f
+	assert.Equal(t, -1, CompareTimeUUID(u3, u4))
+}
+
+func TestCompareTimeUUIDIgnoresLexicalOrder(t *testing.T) {
+	// Lexically greater time_low but smaller time_hi.
+	u1 := Must(FromString("ffffffff-0000-1000-8000-000000000000"))
+	u2 := Must(FromString("00000000-0000-1001-8000-000000000000"))
+	assert.Equal(t, -1, CompareTimeUUID(u1, u2))
+}
+
+func TestNewV1At(t *testing.T) {
+	var node [6]byte
+	copy(node[:], vectors.Node)
+
+	u := Must(NewV1At(vectors.Time, 0x33c8, node))
+	assert.Equal(t, vectors.V1, u.String())
+	assert.Equal(t, u, Must(NewV1At(vectors.Time, 0x33c8, node)))
+
+	// Bits of clock sequence above 14 are taken by variant.
+	assert.Equal(t, u, Must(NewV1At(vectors.Time, 0xf3c8, node)))
+
+	ts, err := timeOf(u)
+	require.NoError(t, err)
+	assert.True(t, vectors.Time.Equal(ts))
+}
+
+func TestNewV6At(t *testing.T) {
+	var node [6]byte
+	copy(node[:], vectors.Node)
+
+	u := Must(NewV6At(vectors.Time, 0x33c8, node))
+	assert.Equal(t, vectors.V6, u.String())
+
+	ts, err := timeOf(u)
+	require.NoError(t, err)
+	assert.True(t, vectors.Time.Equal(ts))
+}
+
+func TestNewV1AtOutOfUnixNanoRange(t *testing.T) {
+	var node [6]byte
+	tests := []struct {
+		t  time.Time
+		ts uint64
+	}{
+		{time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC), 0},
+		{time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC), 5431968000000000},
+		{time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), 226330848000000000},
+		{time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC), 1<<60 - 1},
+	}
+	for _, tt := range tests {
+		u1, err := NewV1At(tt.t, 0, node)
+		require.NoError(t, err)
+		assert.Equal(t, tt.ts, timestampV1(u1), tt.t)
+
+		u6, err := NewV6At(tt.t, 0, node)
+		require.NoError(t, err)
+		assert.Equal(t, tt.ts, timestampV6(u6), tt.t)
+	}
+
+	for _, ts := range []time.Time{
+		time.Date(1582, 10, 14, 23, 59, 59, 999999999, time.UTC),
+		time.Date(5236, 3, 31, 21, 21, 0, 684697600, time.UTC),
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		_, err := NewV1At(ts, 0, node)
+		assert.Error(t, err, ts)
+		_, err = NewV6At(ts, 0, node)
+		assert.Error(t, err, ts)
+	}
+}
+
+func TestTimestampFromV7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	u := MustNewV7()
+	after := time.Now()
+
+	ts, err := TimestampFromV7(u)
+	require.NoError(t, err)
+	assert.False(t, ts.Before(before))
+	assert.False(t, ts.After(after))
+
+	_, err = TimestampFromV7(MustNewV4())
+	assert.Error(t, err)
+}
+
+func TestTimestampFromV7WithEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenerator(WithV7Epoch(epoch))
+
+	before := time.Now().Truncate(time.Millisecond)
+	u, err := g.NewV7()
+	require.NoError(t, err)
+	after := time.Now()
+
+	ts, err := TimestampFromV7(u)
+	require.NoError(t, err)
+	assert.True(t, ts.Before(epoch))
+
+	ts, err = TimestampFromV7WithEpoch(u, epoch)
+	require.NoError(t, err)
+	assert.False(t, ts.Before(before))
+	assert.False(t, ts.After(after))
+}
+
+func TestNewV7BeforeEpoch(t *testing.T) {
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: epoch.Add(-time.Second)}
+	g := NewGenerator(WithV7Epoch(epoch), WithClock(clock))
+
+	u, err := g.NewV7()
+	assert.Error(t, err)
+	assert.Equal(t, Nil, u)
+
+	clock.set(epoch)
+	u, err = g.NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), getUint48(u[:6]))
+
+	// Time before Unix epoch is rejected as well.
+	clock.set(time.Unix(-1, 0))
+	_, err = NewGenerator(WithClock(clock)).NewV7()
+	assert.Error(t, err)
+}
+
+func TestTruncateToTimeBucketV7(t *testing.T) {
+	u := MustFromString("01890a5d-ac96-774b-bcce-b302099a8057")
+
+	b1, err := u.TruncateToTimeBucket(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, V7, b1.Version())
+	assert.Equal(t, VariantRFC4122, b1.Variant())
+	assert.Equal(t, "01890a3e-4380-7000-8000-000000000000", b1.String())
+
+	ts, err := TimestampFromV7(b1)
+	require.NoError(t, err)
+	assert.Equal(t, ts.Truncate(time.Hour), ts)
+
+	key, err := BucketKey(u, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, ts.Unix()/3600, key)
+}
+
+func TestTruncateToTimeBucketV1(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 34, 56, 789, time.UTC)
+	g := &rfc4122Generator{
+		epochFunc:  func() time.Time { return now },
+		hwAddrFunc: defaultHWAddrFunc,
+		rand:       rand.Reader,
+	}
+	u1, err := g.NewV1()
+	require.NoError(t, err)
+
+	now = now.Add(time.Minute)
+	u2, err := g.NewV1()
+	require.NoError(t, err)
+
+	b1, err := u1.TruncateToTimeBucket(time.Hour)
+	require.NoError(t, err)
+	b2, err := u2.TruncateToTimeBucket(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, b1, b2)
+	assert.Equal(t, V1, b1.Version())
+	assert.Equal(t, VariantRFC4122, b1.Variant())
+	assert.Equal(t, epochStart+uint64(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).UnixNano()/100), timestampV1(b1))
+	assert.Equal(t, []byte{0x80, 0, 0, 0, 0, 0, 0, 0}, b1[8:])
+
+	key, err := BucketKey(u1, 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Unix()/86400, key)
+}
+
+func TestTruncateToTimeBucketFarTimestamps(t *testing.T) {
+	var v1, v7 UUID
+	putTimeV1(&v1, 1<<60-1) // year 5236
+	v1 = finalizeUUID(v1, V1)
+	putUint48(v7[:6], 1<<48-1) // year 10889
+	v7 = finalizeUUID(v7, V7)
+
+	tests := []struct {
+		u     UUID
+		key   int64
+		start uint64
+	}{
+		{v1, 28631349, epochStart + 28631349*uint64(time.Hour/100)},
+		{v7, 78187493, 78187493 * uint64(time.Hour/time.Millisecond)},
+	}
+	for _, tt := range tests {
+		key, err := BucketKey(tt.u, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, tt.key, key)
+
+		b, err := tt.u.TruncateToTimeBucket(time.Hour)
+		require.NoError(t, err)
+		if b.Version() == V1 {
+			assert.Equal(t, tt.start, timestampV1(b))
+		} else {
+			assert.Equal(t, tt.start, getUint48(b[:6]))
+		}
+	}
+
+	// Index of 1ns bucket doesn't fit int64.
+	_, err := BucketKey(v7, time.Nanosecond)
+	assert.Error(t, err)
+}
+
+func TestTruncateToTimeBucketBeforeUnixEpoch(t *testing.T) {
+	var u UUID
+	putTimeV1(&u, epochStart-1)
+	u = finalizeUUID(u, V1)
+
+	key, err := BucketKey(u, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), key)
+
+	b, err := u.TruncateToTimeBucket(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, epochStart-uint64(time.Hour/100), timestampV1(b))
+}
+
+func TestTruncateToTimeBucketInvalid(t *testing.T) {
+	_, err := MustNewV4().TruncateToTimeBucket(time.Hour)
+	assert.Error(t, err)
+
+	_, err = MustNewV7().TruncateToTimeBucket(0)
+	assert.Error(t, err)
+
+	_, err = BucketKey(MustNewV4(), time.Hour)
+	assert.Error(t, err)
+}
+
+func BenchmarkCompareTimeUUID(b *testing.B) {
+	u1 := Must(NewV1())
+	u2 := Must(NewV1())
+	for i := 0; i < b.N; i++ {
+		CompareTimeUUID(u1, u2)
+	}
+}
+
+func TestAge(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	g := newClockGenerator(ClockRegressionIncrement, past)
+	for _, newFn := range []func() (UUID, error){g.NewV1, g.NewV6, g.NewV7} {
+		u, err := newFn()
+		require.NoError(t, err)
+
+		age, err := Age(u)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Hour, age, float64(time.Minute), "version %d", u.Version())
+
+		old, err := IsOlderThan(u, 30*time.Minute)
+		require.NoError(t, err)
+		assert.True(t, old)
+
+		old, err = IsOlderThan(u, 2*time.Hour)
+		require.NoError(t, err)
+		assert.False(t, old)
+	}
+}
+
+func TestAgeOutOfUnixNanoRange(t *testing.T) {
+	first := time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+	last := time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)
+	var v1First, v1Last, v6First, v6Last, v7Last UUID
+	putTimeV1(&v1First, 0)
+	putTimeV1(&v1Last, 1<<60-1)
+	putTimeV6(&v6First, 0)
+	putTimeV6(&v6Last, 1<<60-1)
+	putUint48(v7Last[:6], 1<<48-1)
+
+	tests := []struct {
+		u        UUID
+		expected time.Time
+	}{
+		{finalizeUUID(v1First, V1), first},
+		{finalizeUUID(v1Last, V1), last},
+		{finalizeUUID(v6First, V6), first},
+		{finalizeUUID(v6Last, V6), last},
+		{finalizeUUID(v7Last, V7), time.UnixMilli(1<<48 - 1)},
+	}
+	for _, tt := range tests {
+		ts, err := timeOf(tt.u)
+		require.NoError(t, err)
+		assert.True(t, tt.expected.Equal(ts), tt.u, ts)
+
+		old, err := IsOlderThan(tt.u, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected.Before(time.Now()), old, tt.u)
+	}
+
+	assert.True(t, epochTime(epochStart-1).Equal(time.Unix(0, -100)))
+}
+
+func TestAgeInvalid(t *testing.T) {
+	_, err := Age(Nil)
+	assert.Error(t, err)
+
+	_, err = IsOlderThan(Must(NewV4()), time.Hour)
+	assert.Error(t, err)
+}
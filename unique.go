@@ -0,0 +1,87 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Number of UUIDs above which CheckUnique sorts instead of hashing,
+// as sorting needs less memory than a hash set of the same size.
+const checkUniqueSortThreshold = 1 << 20
+
+// CheckUnique reports whether ids hold no duplicates, e.g. to validate
+// imported datasets. If they don't, dupIndex is the smallest index of
+// an element equal to one found earlier. Inputs are checked with a hash
+// set, except huge ones, which are checked by sorting their indices,
+// leaving ids unmodified.
+func CheckUnique(ids []UUID) (dupIndex int, ok bool) {
+	if len(ids) > checkUniqueSortThreshold {
+		return checkUniqueSorted(ids)
+	}
+	return CheckUniqueSeq(slices.Values(ids))
+}
+
+// CheckUniqueSeq reports whether seq yields no duplicates. Same behavior
+// as CheckUnique, except that it stops at the first duplicate and always
+// uses a hash set, growing with the number of UUIDs yielded so far.
+func CheckUniqueSeq(seq iter.Seq[UUID]) (dupIndex int, ok bool) {
+	seen := make(map[UUID]struct{})
+	i := 0
+	for u := range seq {
+		if _, dup := seen[u]; dup {
+			return i, false
+		}
+		seen[u] = struct{}{}
+		i++
+	}
+	return -1, true
+}
+
+// Checks uniqueness by sorting indices of ids by their UUIDs.
+func checkUniqueSorted(ids []UUID) (dupIndex int, ok bool) {
+	idx := make([]int, len(ids))
+	for i := range idx {
+		idx[i] = i
+	}
+	slices.SortFunc(idx, func(a, b int) int {
+		if c := bytes.Compare(ids[a][:], ids[b][:]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	dupIndex = -1
+	for i := 1; i < len(idx); i++ {
+		// Indices of equal UUIDs are sorted, so the second one of each
+		// run is the first duplicate of its UUID.
+		if ids[idx[i]] == ids[idx[i-1]] && (i < 2 || ids[idx[i]] != ids[idx[i-2]]) {
+			if dupIndex < 0 || idx[i] < dupIndex {
+				dupIndex = idx[i]
+			}
+		}
+	}
+	return dupIndex, dupIndex < 0
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+func TestCheckUnique(t *testing.T) {
+	a, b, c := NamespaceDNS, NamespaceURL, NamespaceOID
+	tests := []struct {
+		ids []UUID
+		dup int
+	}{
+		{nil, -1},
+		{[]UUID{a}, -1},
+		{[]UUID{a, b, c}, -1},
+		{[]UUID{a, b, a}, 2},
+		{[]UUID{c, a, b, b, a}, 3},
+		{[]UUID{a, a, a}, 1},
+		{[]UUID{Nil, Nil}, 1},
+	}
+	for _, tt := range tests {
+		dup, ok := CheckUnique(tt.ids)
+		assert.Equal(t, tt.dup, dup, tt.ids)
+		assert.Equal(t, tt.dup < 0, ok)
+
+		dup, ok = checkUniqueSorted(tt.ids)
+		assert.Equal(t, tt.dup, dup, tt.ids)
+		assert.Equal(t, tt.dup < 0, ok)
+
+		dup, ok = CheckUniqueSeq(slices.Values(tt.ids))
+		assert.Equal(t, tt.dup, dup, tt.ids)
+		assert.Equal(t, tt.dup < 0, ok)
+	}
+}
+
+func TestCheckUniqueSortedRandom(t *testing.T) {
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = Must(NewV4())
+	}
+	dup, ok := checkUniqueSorted(ids)
+	assert.True(t, ok)
+	assert.Equal(t, -1, dup)
+
+	ids[700] = ids[900]
+	ids[800] = ids[10]
+	dup, ok = checkUniqueSorted(ids)
+	assert.False(t, ok)
+	assert.Equal(t, 800, dup)
+}
+
+func TestCheckUniqueSeqStops(t *testing.T) {
+	n := 0
+	seq := func(yield func(UUID) bool) {
+		for _, u := range []UUID{NamespaceDNS, NamespaceDNS, NamespaceURL} {
+			n++
+			if !yield(u) {
+				return
+			}
+		}
+	}
+	dup, ok := CheckUniqueSeq(seq)
+	assert.False(t, ok)
+	assert.Equal(t, 1, dup)
+	assert.Equal(t, 2, n)
+}
+
+func BenchmarkCheckUnique(b *testing.B) {
+	ids := make([]UUID, 10000)
+	for i := range ids {
+		ids[i] = Must(NewV4())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = CheckUnique(ids)
+	}
+}
@@ -26,7 +26,8 @@ package uuid
 
 import (
 	"bytes"
-	"encoding/hex"
+
+	"github.com/satori/go.uuid/uuidcodec"
 )
 
 // Size of a UUID in bytes.
@@ -46,6 +47,7 @@ const (
 	V5
 	V6
 	V7
+	V8
 )
 
 // UUID layout variants.
@@ -58,21 +60,28 @@ const (
 
 // UUID DCE domains.
 const (
-	DomainPerson = iota
+	DomainPerson Domain = iota
 	DomainGroup
 	DomainOrg
 )
 
+// Lowercase hexadecimal digits.
+const hexDigits = "0123456789abcdef"
+
 // String parse helpers.
 var (
-	urnPrefix  = []byte("urn:uuid:")
-	byteGroups = []int{8, 4, 4, 4, 12}
+	urnPrefix  = []byte(uuidcodec.URNPrefix)
+	byteGroups = uuidcodec.GroupLengths()
 )
 
 // Nil is special form of UUID that is specified to have all
 // 128 bits set to zero.
 var Nil = UUID{}
 
+// Max is special form of UUID that is specified to have all
+// 128 bits set to one.
+var Max = UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
 // Predefined namespace UUIDs.
 var (
 	NamespaceDNS  = Must(FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
@@ -86,6 +95,15 @@ func Equal(u1 UUID, u2 UUID) bool {
 	return bytes.Equal(u1[:], u2[:])
 }
 
+// EqualPtr returns true if both a and b are nil or both point to equal
+// UUIDs, otherwise returns false.
+func EqualPtr(a, b *UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // Version returns algorithm version used to generate UUID.
 func (u UUID) Version() byte {
 	return u[6] >> 4
@@ -112,30 +130,81 @@ func (u UUID) Bytes() []byte {
 	return u[:]
 }
 
+// Array returns UUID as a 16 bytes array, the representation of
+// Parquet UUID logical type, which annotates FIXED_LEN_BYTE_ARRAY(16).
+func (u UUID) Array() [Size]byte {
+	return u
+}
+
 // Returns canonical string representation of UUID:
 // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
 func (u UUID) String() string {
-	buf := make([]byte, 36)
-
-	hex.Encode(buf[0:8], u[0:4])
-	buf[8] = '-'
-	hex.Encode(buf[9:13], u[4:6])
-	buf[13] = '-'
-	hex.Encode(buf[14:18], u[6:8])
-	buf[18] = '-'
-	hex.Encode(buf[19:23], u[8:10])
-	buf[23] = '-'
-	hex.Encode(buf[24:], u[10:])
+	var buf [36]byte
+	encodeCanonical(buf[:], u)
+	return string(buf[:])
+}
 
-	return string(buf)
+// Writes canonical string representation of UUID into 36 bytes of dst.
+// The encoding is fully unrolled, with each byte encoded by a single
+// lookup, as it's on the hot path of bulk exports.
+func encodeCanonical(dst []byte, u UUID) {
+	_ = dst[35] // bounds check hint to compiler
+	// time_low
+	dst[0], dst[1] = hexPairs[u[0]][0], hexPairs[u[0]][1]
+	dst[2], dst[3] = hexPairs[u[1]][0], hexPairs[u[1]][1]
+	dst[4], dst[5] = hexPairs[u[2]][0], hexPairs[u[2]][1]
+	dst[6], dst[7] = hexPairs[u[3]][0], hexPairs[u[3]][1]
+	dst[8] = '-'
+	// time_mid
+	dst[9], dst[10] = hexPairs[u[4]][0], hexPairs[u[4]][1]
+	dst[11], dst[12] = hexPairs[u[5]][0], hexPairs[u[5]][1]
+	dst[13] = '-'
+	// time_hi_and_version
+	dst[14], dst[15] = hexPairs[u[6]][0], hexPairs[u[6]][1]
+	dst[16], dst[17] = hexPairs[u[7]][0], hexPairs[u[7]][1]
+	dst[18] = '-'
+	// clock_seq
+	dst[19], dst[20] = hexPairs[u[8]][0], hexPairs[u[8]][1]
+	dst[21], dst[22] = hexPairs[u[9]][0], hexPairs[u[9]][1]
+	dst[23] = '-'
+	// node
+	dst[24], dst[25] = hexPairs[u[10]][0], hexPairs[u[10]][1]
+	dst[26], dst[27] = hexPairs[u[11]][0], hexPairs[u[11]][1]
+	dst[28], dst[29] = hexPairs[u[12]][0], hexPairs[u[12]][1]
+	dst[30], dst[31] = hexPairs[u[13]][0], hexPairs[u[13]][1]
+	dst[32], dst[33] = hexPairs[u[14]][0], hexPairs[u[14]][1]
+	dst[34], dst[35] = hexPairs[u[15]][0], hexPairs[u[15]][1]
 }
 
+// Pairs of lowercase hexadecimal digits of every byte value,
+// a 512 bytes lookup table.
+var hexPairs = func() (pairs [256][2]byte) {
+	for i := range pairs {
+		pairs[i] = [2]byte{hexDigits[i>>4], hexDigits[i&0x0f]}
+	}
+	return
+}()
+
 // SetVersion sets version bits.
+// It modifies u in place, see WithVersion for a copy.
 func (u *UUID) SetVersion(v byte) {
 	u[6] = (u[6] & 0x0f) | (v << 4)
 }
 
+// WithVersion returns copy of u with version bits set, leaving u intact.
+func (u UUID) WithVersion(v byte) UUID {
+	u.SetVersion(v)
+	return u
+}
+
+// WithVariant returns copy of u with variant bits set, leaving u intact.
+func (u UUID) WithVariant(v byte) UUID {
+	u.SetVariant(v)
+	return u
+}
+
 // SetVariant sets variant bits.
+// It modifies u in place, see WithVariant for a copy.
 func (u *UUID) SetVariant(v byte) {
 	switch v {
 	case VariantNCS:
@@ -162,3 +231,21 @@ func Must(u UUID, err error) UUID {
 	}
 	return u
 }
+
+// MustNewV4 returns random generated UUID.
+// Same behavior as NewV4, but panics on error.
+func MustNewV4() UUID {
+	return Must(NewV4())
+}
+
+// MustNewV7 returns UUID v7.
+// Same behavior as NewV7, but panics on error.
+func MustNewV7() UUID {
+	return Must(NewV7())
+}
+
+// MustFromString returns UUID parsed from string input.
+// Same behavior as FromString, but panics on error.
+func MustFromString(input string) UUID {
+	return Must(FromString(input))
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"cmp"
+	"database/sql/driver"
+)
+
+// UUID128 is a UUID represented as its high and low 64 bits, taken as
+// big-endian unsigned integers. It holds the same value as UUID and
+// converts to and from it losslessly, but compares and hashes as two
+// machine words, which may make it a faster map key in hot paths.
+type UUID128 struct {
+	Hi, Lo uint64
+}
+
+// UUID128Of returns u as UUID128, made of the pair returned
+// by ToUint128.
+func UUID128Of(u UUID) UUID128 {
+	hi, lo := u.ToUint128()
+	return UUID128{Hi: hi, Lo: lo}
+}
+
+// UUID returns v as UUID, see FromUint64Pair.
+func (v UUID128) UUID() UUID {
+	return FromUint64Pair(v.Hi, v.Lo)
+}
+
+// FromString128 returns UUID128 parsed from string input.
+// Input is expected in a form accepted by UnmarshalText.
+func FromString128(input string) (UUID128, error) {
+	u, err := FromString(input)
+	return UUID128Of(u), err
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is less than,
+// equal to or greater than other. The order is the same as bytewise
+// order of the corresponding UUIDs.
+func (v UUID128) Compare(other UUID128) int {
+	if c := cmp.Compare(v.Hi, other.Hi); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.Lo, other.Lo)
+}
+
+// IsNil reports whether v is the Nil UUID.
+func (v UUID128) IsNil() bool {
+	return v.Hi == 0 && v.Lo == 0
+}
+
+// Version returns algorithm version used to generate UUID.
+func (v UUID128) Version() byte {
+	return byte(v.Hi>>12) & 0x0f
+}
+
+// Variant returns UUID layout variant.
+func (v UUID128) Variant() byte {
+	return v.UUID().Variant()
+}
+
+// Bytes returns bytes slice representation of UUID.
+func (v UUID128) Bytes() []byte {
+	return v.UUID().Bytes()
+}
+
+// String returns canonical string representation of UUID.
+func (v UUID128) String() string {
+	return v.UUID().String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (v UUID128) MarshalText() ([]byte, error) {
+	return v.UUID().MarshalText()
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v UUID128) MarshalJSON() ([]byte, error) {
+	return v.UUID().MarshalJSON()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same formats as UUID.UnmarshalText.
+func (v *UUID128) UnmarshalText(text []byte) error {
+	var u UUID
+	if err := u.UnmarshalText(text); err != nil {
+		return err
+	}
+	*v = UUID128Of(u)
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (v UUID128) MarshalBinary() ([]byte, error) {
+	return v.UUID().MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It will return error if the slice isn't 16 bytes long.
+func (v *UUID128) UnmarshalBinary(data []byte) error {
+	var u UUID
+	if err := u.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*v = UUID128Of(u)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (v UUID128) Value() (driver.Value, error) {
+	return v.UUID().Value()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same values as UUID.Scan.
+func (v *UUID128) Scan(src interface{}) error {
+	var u UUID
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+	*v = UUID128Of(u)
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"testing/quick"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestUUID128Conversion(t *testing.T) {
+	v := UUID128Of(NamespaceDNS)
+	assert.Equal(t, UUID128{Hi: 0x6ba7b8109dad11d1, Lo: 0x80b400c04fd430c8}, v)
+	assert.Equal(t, NamespaceDNS, v.UUID())
+	assert.True(t, UUID128Of(Nil).IsNil())
+	assert.False(t, v.IsNil())
+
+	assert.NoError(t, quick.Check(func(u UUID) bool {
+		return UUID128Of(u).UUID() == u
+	}, nil))
+}
+
+func TestUUID128Accessors(t *testing.T) {
+	for _, u := range []UUID{NamespaceDNS, Must(NewV4()), Must(NewV7()), Max} {
+		v := UUID128Of(u)
+		assert.Equal(t, u.Version(), v.Version())
+		assert.Equal(t, u.Variant(), v.Variant())
+		assert.Equal(t, u.Bytes(), v.Bytes())
+		assert.Equal(t, u.String(), v.String())
+	}
+}
+
+func TestUUID128Compare(t *testing.T) {
+	assert.NoError(t, quick.Check(func(a, b UUID) bool {
+		return UUID128Of(a).Compare(UUID128Of(b)) == bytes.Compare(a[:], b[:])
+	}, nil))
+	assert.Equal(t, 0, UUID128Of(NamespaceDNS).Compare(UUID128Of(NamespaceDNS)))
+	assert.Equal(t, -1, UUID128Of(NamespaceDNS).Compare(UUID128Of(NamespaceURL)))
+}
+
+func TestUUID128Codecs(t *testing.T) {
+	v, err := FromString128("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	assert.Equal(t, UUID128Of(NamespaceDNS), v)
+	_, err = FromString128("invalid")
+	assert.Error(t, err)
+
+	data, err := json.Marshal(map[string]UUID128{"id": v})
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`, string(data))
+	var m map[string]UUID128
+	require.NoError(t, json.Unmarshal(data, &m))
+	assert.Equal(t, v, m["id"])
+
+	// Used as JSON object key via text marshaling.
+	data, err = json.Marshal(map[UUID128]int{v: 1})
+	require.NoError(t, err)
+	var keys map[UUID128]int
+	require.NoError(t, json.Unmarshal(data, &keys))
+	assert.Equal(t, 1, keys[v])
+
+	b, err := v.MarshalBinary()
+	require.NoError(t, err)
+	var w UUID128
+	require.NoError(t, w.UnmarshalBinary(b))
+	assert.Equal(t, v, w)
+	assert.Error(t, w.UnmarshalBinary(b[:3]))
+
+	val, err := v.Value()
+	require.NoError(t, err)
+	w = UUID128{}
+	require.NoError(t, w.Scan(val))
+	assert.Equal(t, v, w)
+	assert.Error(t, w.Scan(42))
+	assert.Equal(t, v, w)
+}
+
+func benchmarkMap[K comparable](b *testing.B, key func(UUID) K) {
+	m := make(map[K]int)
+	keys := make([]K, 1024)
+	for i := range keys {
+		keys[i] = key(Must(NewV4()))
+		m[keys[i]] = i
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = m[keys[i%len(keys)]]
+	}
+}
+
+func BenchmarkMapLookupUUID(b *testing.B) {
+	benchmarkMap(b, func(u UUID) UUID { return u })
+}
+
+func BenchmarkMapLookupUUID128(b *testing.B) {
+	benchmarkMap(b, UUID128Of)
+}
@@ -24,8 +24,10 @@ package uuid
 import (
 	"bytes"
 	"fmt"
-	"github.com/stretchr/testify/assert"
 	"testing"
+	"testing/quick"
+
+	"github.com/satori/go.uuid/internal/assert"
 )
 
 func TestBytes(t *testing.T) {
@@ -35,15 +37,61 @@ func TestBytes(t *testing.T) {
 	assert.True(t, bytes.Equal(u.Bytes(), expectedBytes))
 }
 
+func TestArray(t *testing.T) {
+	a := [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	assert.Equal(t, a, NamespaceDNS.Array())
+}
+
 func TestString(t *testing.T) {
 	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", NamespaceDNS.String())
 }
 
+// Straightforward canonical encoding to check the unrolled one against.
+func referenceString(u UUID) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+}
+
+func TestStringMatchesReference(t *testing.T) {
+	assert.Equal(t, referenceString(Nil), Nil.String())
+	assert.Equal(t, referenceString(Max), Max.String())
+	assert.NoError(t, quick.CheckEqual(referenceString, UUID.String, nil))
+}
+
+func BenchmarkString(b *testing.B) {
+	u := NamespaceDNS
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = u.String()
+	}
+}
+
+func BenchmarkEncodeCanonical(b *testing.B) {
+	var buf [36]byte
+	u := NamespaceDNS
+	for i := 0; i < b.N; i++ {
+		EncodeCanonical(buf[:], u)
+	}
+}
+
+func TestMax(t *testing.T) {
+	assert.Equal(t, "ffffffff-ffff-ffff-ffff-ffffffffffff", Max.String())
+}
+
 func TestEqual(t *testing.T) {
 	assert.True(t, Equal(NamespaceDNS, NamespaceDNS))
 	assert.False(t, Equal(NamespaceDNS, NamespaceURL))
 }
 
+func TestEqualPtr(t *testing.T) {
+	u1, u2, u3 := NamespaceDNS, NamespaceDNS, NamespaceURL
+	assert.True(t, EqualPtr(nil, nil))
+	assert.True(t, EqualPtr(&u1, &u1))
+	assert.True(t, EqualPtr(&u1, &u2))
+	assert.False(t, EqualPtr(&u1, &u3))
+	assert.False(t, EqualPtr(&u1, nil))
+	assert.False(t, EqualPtr(nil, &u1))
+}
+
 func TestVersion(t *testing.T) {
 	u := UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
 	assert.Equal(t, V1, u.Version())
@@ -55,6 +103,28 @@ func TestSetVersion(t *testing.T) {
 	assert.Equal(t, V4, u.Version())
 }
 
+func TestWithVersion(t *testing.T) {
+	u := NamespaceDNS
+	v := u.WithVersion(V4)
+	assert.Equal(t, V4, v.Version())
+	assert.Equal(t, NamespaceDNS, u)
+
+	expected := NamespaceDNS
+	expected.SetVersion(V4)
+	assert.Equal(t, expected, v)
+}
+
+func TestWithVariant(t *testing.T) {
+	u := NamespaceDNS
+	for _, variant := range []byte{VariantNCS, VariantRFC4122, VariantMicrosoft, VariantFuture} {
+		v := u.WithVariant(variant)
+		assert.Equal(t, variant, v.Variant())
+		assert.Equal(t, V1, v.Version())
+	}
+	assert.Equal(t, NamespaceDNS, u)
+	assert.Equal(t, Nil.WithVersion(V8).WithVariant(VariantRFC4122), finalizeUUID(Nil, V8))
+}
+
 func TestVariant(t *testing.T) {
 	u1 := UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
 	assert.Equal(t, VariantNCS, u1.Variant())
@@ -91,3 +161,22 @@ func TestMust(t *testing.T) {
 		}())
 	})
 }
+
+func TestMustNewV4(t *testing.T) {
+	u := MustNewV4()
+	assert.Equal(t, V4, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+}
+
+func TestMustNewV7(t *testing.T) {
+	u := MustNewV7()
+	assert.Equal(t, V7, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+}
+
+func TestMustFromString(t *testing.T) {
+	assert.Equal(t, NamespaceDNS, MustFromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	assert.Panics(t, func() {
+		MustFromString("invalid")
+	})
+}
@@ -0,0 +1,76 @@
+package a
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func ignoredErrors() {
+	u1, _ := uuid.NewV4()                                               // want `error returned by uuid.NewV4 is ignored`
+	var u2, _ = uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8") // want `error returned by uuid.FromString is ignored`
+	var u3 uuid.UUID
+	u3.UnmarshalText(nil) // want `error returned by uuid.UUID.UnmarshalText is ignored`
+	g := uuid.NewGenerator()
+	u4, _ := g.NewV4() // want `error returned by uuid.Generator.NewV4 is ignored`
+	fmt.Println(u1, u2, u3, u4)
+}
+
+func handledErrors() error {
+	u1, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	u2 := uuid.Must(uuid.NewV4())
+	u3 := uuid.NewV5(u1, "name")
+	_ = u3.UnmarshalText(nil)
+	fmt.Println(u2, u3)
+	return nil
+}
+
+func comparisons(u, v uuid.UUID, n uuid.NullUUID, d uuid.Domain, s string) bool {
+	switch {
+	case u.String() == s: // want `UUID compared as text`
+	case s != n.UUID.String(): // want `UUID compared as text`
+	case u.String() == "6BA7B810-9DAD-11D1-80B4-00C04FD430C8": // want `UUID compared as text`
+	case u.String() == v.String():
+	case u == v:
+	case d.String() == "Person":
+	case s == uuid.DomainPerson.String():
+	}
+	return false
+}
+
+// BinaryID is stored in BINARY(16) column.
+type BinaryID uuid.UUID
+
+func (id BinaryID) Value() (driver.Value, error) {
+	return uuid.UUID(id).String(), nil // want `UUID text returned by Value of binary column type BinaryID`
+}
+
+// BlobKey is stored in a BLOB column.
+type BlobKey struct {
+	ID uuid.UUID
+}
+
+func (k *BlobKey) Value() (driver.Value, error) {
+	if k == nil {
+		return nil, nil
+	}
+	return k.ID.StringCompact(), nil // want `UUID text returned by Value of binary column type BlobKey`
+}
+
+// TextID is stored in CHAR(36) column.
+type TextID uuid.UUID
+
+func (id TextID) Value() (driver.Value, error) {
+	return uuid.UUID(id).String(), nil
+}
+
+// RawBinaryID is stored as raw bytes.
+type RawBinaryID uuid.UUID
+
+func (id RawBinaryID) Value() (driver.Value, error) {
+	return uuid.UUID(id).Bytes(), nil
+}
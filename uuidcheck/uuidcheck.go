@@ -0,0 +1,307 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package uuidcheck defines an analyzer reporting common misuses of
+// github.com/satori/go.uuid package:
+//
+//   - ignoring the error returned by generating or parsing functions
+//     while using their result, e.g. u, _ := uuid.NewV4(), which yields
+//     Nil UUID on failure, or calling them as a statement;
+//   - comparing text of UUID with a string, e.g. u.String() == s, which
+//     is sensitive to case and form of s; parse s and compare UUIDs;
+//   - returning text of UUID, e.g. u.String(), from the Value method of
+//     a driver.Valuer whose type is named or documented as binary or
+//     blob, i.e. mapped to a BINARY(16) column, which expects u.Bytes().
+//
+// Run it with go vet:
+//
+//	go install github.com/satori/go.uuid/uuidcheck/cmd/uuidcheck@latest
+//	go vet -vettool=$(which uuidcheck) ./...
+package uuidcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Path of the checked package.
+const uuidPath = "github.com/satori/go.uuid"
+
+// Analyzer reports common misuses of UUID package.
+var Analyzer = &analysis.Analyzer{
+	Name:     "uuidcheck",
+	Doc:      "report common misuses of github.com/satori/go.uuid package",
+	URL:      "https://pkg.go.dev/github.com/satori/go.uuid/uuidcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if pass.Pkg.Path() == uuidPath {
+		return nil, nil
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	binaryTypes := binaryTypes(pass)
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.FuncDecl)(nil),
+	}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 {
+				checkIgnoredError(pass, n.Lhs, n.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 {
+				lhs := make([]ast.Expr, len(n.Names))
+				for i, name := range n.Names {
+					lhs[i] = name
+				}
+				checkIgnoredError(pass, lhs, n.Values[0])
+			}
+		case *ast.ExprStmt:
+			if fn := uuidFuncReturningError(pass, n.X); fn != nil {
+				pass.ReportRangef(n, "error returned by %s is ignored", name(fn))
+			}
+		case *ast.BinaryExpr:
+			checkStringComparison(pass, n)
+		case *ast.FuncDecl:
+			checkBinaryValuer(pass, n, binaryTypes)
+		}
+	})
+	return nil, nil
+}
+
+// Reports error result of UUID package function assigned to blank
+// while other results are used.
+func checkIgnoredError(pass *analysis.Pass, lhs []ast.Expr, rhs ast.Expr) {
+	fn := uuidFuncReturningError(pass, rhs)
+	if fn == nil {
+		return
+	}
+	results := fn.Type().(*types.Signature).Results()
+	if len(lhs) != results.Len() || !isBlank(lhs[len(lhs)-1]) {
+		return
+	}
+	// Discarding all results, as in _ = u.UnmarshalText(text),
+	// is deliberate; using a result while the error is discarded isn't.
+	for _, e := range lhs[:len(lhs)-1] {
+		if !isBlank(e) {
+			pass.ReportRangef(rhs, "error returned by %s is ignored", name(fn))
+			return
+		}
+	}
+}
+
+// Returns true if expr is the blank identifier.
+func isBlank(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+// Returns function of UUID package called by expr if its last
+// result is error.
+func uuidFuncReturningError(pass *analysis.Pass, expr ast.Expr) *types.Func {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	fn := calledFunc(pass, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != uuidPath {
+		return nil
+	}
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() == 0 {
+		return nil
+	}
+	last := results.At(results.Len() - 1).Type()
+	if !types.Identical(last, types.Universe.Lookup("error").Type()) {
+		return nil
+	}
+	return fn
+}
+
+// Reports comparison of UUID text with a string.
+func checkStringComparison(pass *analysis.Pass, expr *ast.BinaryExpr) {
+	if expr.Op != token.EQL && expr.Op != token.NEQ {
+		return
+	}
+	for _, pair := range [2][2]ast.Expr{{expr.X, expr.Y}, {expr.Y, expr.X}} {
+		if isUUIDString(pass, pair[0]) && !isUUIDString(pass, pair[1]) {
+			pass.ReportRangef(expr, "UUID compared as text is sensitive to case and form of the other operand; parse it and compare UUIDs")
+			return
+		}
+	}
+}
+
+// Returns true if expr calls String method of UUID type.
+// String methods of other types of UUID package are not considered.
+// Returns types declared in the package which are named or documented
+// as binary or blob, i.e. meant for BINARY(16) or BLOB columns.
+func binaryTypes(pass *analysis.Pass) map[*types.TypeName]bool {
+	res := make(map[*types.TypeName]bool)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				doc := spec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				text := spec.Name.Name
+				if doc != nil {
+					text += " " + doc.Text()
+				}
+				text = strings.ToLower(text)
+				if strings.Contains(text, "binary") || strings.Contains(text, "blob") {
+					if obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName); ok {
+						res[obj] = true
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+func checkBinaryValuer(pass *analysis.Pass, decl *ast.FuncDecl, binaryTypes map[*types.TypeName]bool) {
+	if decl.Recv == nil || decl.Body == nil || decl.Name.Name != "Value" {
+		return
+	}
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok || !isValuer(fn) || !binaryTypes[recvTypeName(fn)] {
+		return
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == 2 && isUUIDText(pass, n.Results[0]) {
+				pass.ReportRangef(n.Results[0], "UUID text returned by Value of binary column type %s; return Bytes()", recvTypeName(fn).Name())
+			}
+		}
+		return true
+	})
+}
+
+// Returns true if fn has the signature of driver.Valuer.Value.
+func isValuer(fn *types.Func) bool {
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() != 2 {
+		return false
+	}
+	named, ok := results.At(0).Type().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "Value" && obj.Pkg() != nil && obj.Pkg().Path() == "database/sql/driver"
+}
+
+// Returns name of receiver type of method fn.
+func recvTypeName(fn *types.Func) *types.TypeName {
+	t := fn.Type().(*types.Signature).Recv().Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+func isUUIDString(pass *analysis.Pass, expr ast.Expr) bool {
+	return isUUIDMethodCall(pass, expr, "String")
+}
+
+func isUUIDText(pass *analysis.Pass, expr ast.Expr) bool {
+	return isUUIDMethodCall(pass, expr, "String", "StringCompact")
+}
+
+func isUUIDMethodCall(pass *analysis.Pass, expr ast.Expr, names ...string) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	fn := calledFunc(pass, call)
+	if fn == nil || !slices.Contains(names, fn.Name()) {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return false
+	}
+	t := recv.Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "UUID" && obj.Pkg() != nil && obj.Pkg().Path() == uuidPath
+}
+
+// Returns function or method called by call, nil if it's not static.
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := pass.TypesInfo.Uses[id].(*types.Func)
+	return fn
+}
+
+// Returns name of function as written by users of UUID package.
+func name(fn *types.Func) string {
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		t := recv.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return "uuid." + named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return "uuid." + fn.Name()
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package uuidcodec provides low-level primitives of UUID text
+// representation: lengths of supported forms, the URN prefix and
+// offsets of fields in both binary and canonical text forms, for
+// systems embedding UUID parsing into their own tokenizers.
+//
+// The canonical text form consists of five groups of hex digits
+// separated by dashes, one group per field:
+//
+//	6ba7b810-9dad-11d1-80b4-00c04fd430c8
+//	time_low-time_mid-time_hi_and_version-clock_seq-node
+package uuidcodec
+
+import "fmt"
+
+// Size of a UUID in bytes.
+const Size = 16
+
+// Lengths of UUID text forms.
+const (
+	// CanonicalLen is length of "6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+	CanonicalLen = 36
+	// HashLikeLen is length of "6ba7b8109dad11d180b400c04fd430c8".
+	HashLikeLen = 32
+	// BracedLen is length of "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}".
+	BracedLen = CanonicalLen + 2
+	// BracedHashLikeLen is length of "{6ba7b8109dad11d180b400c04fd430c8}".
+	BracedHashLikeLen = HashLikeLen + 2
+	// URNLen is length of "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8".
+	URNLen = len(URNPrefix) + CanonicalLen
+	// URNHashLikeLen is length of "urn:uuid:6ba7b8109dad11d180b400c04fd430c8".
+	URNHashLikeLen = len(URNPrefix) + HashLikeLen
+)
+
+// URNPrefix precedes UUID in its URN form, as defined by RFC 4122.
+const URNPrefix = "urn:uuid:"
+
+// Field is one of UUID fields, as defined by RFC 4122.
+type Field int
+
+const (
+	TimeLow Field = iota
+	TimeMid
+	TimeHiAndVersion
+	ClockSeq
+	Node
+)
+
+// NumFields is the number of UUID fields.
+const NumFields = 5
+
+// Offsets of fields in binary form, followed by its size.
+var byteOffsets = [NumFields + 1]int{0, 4, 6, 8, 10, Size}
+
+// String returns RFC 4122 name of the field.
+func (f Field) String() string {
+	switch f {
+	case TimeLow:
+		return "time_low"
+	case TimeMid:
+		return "time_mid"
+	case TimeHiAndVersion:
+		return "time_hi_and_version"
+	case ClockSeq:
+		return "clock_seq"
+	case Node:
+		return "node"
+	default:
+		return fmt.Sprintf("Field(%d)", int(f))
+	}
+}
+
+// ByteRange returns offsets of the field in binary form,
+// such that the field is b[start:end]. It panics for unknown field.
+func (f Field) ByteRange() (start, end int) {
+	return byteOffsets[f], byteOffsets[f+1]
+}
+
+// TextRange returns offsets of the field in canonical text form,
+// such that the field is text[start:end]. It panics for unknown field.
+func (f Field) TextRange() (start, end int) {
+	start, end = f.ByteRange()
+	// Each byte is two hex digits, each preceding field adds a dash.
+	return 2*start + int(f), 2*end + int(f)
+}
+
+// GroupLengths returns numbers of hex digits of fields in canonical
+// text form, in order: 8, 4, 4, 4 and 12.
+func GroupLengths() []int {
+	res := make([]int, NumFields)
+	for f := range res {
+		start, end := Field(f).ByteRange()
+		res[f] = 2 * (end - start)
+	}
+	return res
+}
+
+// IsDash reports whether canonical text form has a dash at offset.
+func IsDash(offset int) bool {
+	return offset == 8 || offset == 13 || offset == 18 || offset == 23
+}
+
+// Bytes returns the field of UUID b in binary form.
+// It panics if b is shorter than Size.
+func Bytes(b []byte, f Field) []byte {
+	start, end := f.ByteRange()
+	return b[start:end]
+}
+
+// Text returns the field of UUID text in canonical form.
+// It panics if text is shorter than CanonicalLen.
+func Text(text []byte, f Field) []byte {
+	start, end := f.TextRange()
+	return text[start:end]
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuidcodec
+
+import (
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+)
+
+const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+func TestLengths(t *testing.T) {
+	assert.Equal(t, 36, CanonicalLen)
+	assert.Equal(t, 32, HashLikeLen)
+	assert.Equal(t, 38, BracedLen)
+	assert.Equal(t, 34, BracedHashLikeLen)
+	assert.Equal(t, 45, URNLen)
+	assert.Equal(t, 41, URNHashLikeLen)
+	assert.Equal(t, []int{8, 4, 4, 4, 12}, GroupLengths())
+}
+
+func TestFields(t *testing.T) {
+	u := []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	tests := []struct {
+		field Field
+		name  string
+		text  string
+		bytes []byte
+	}{
+		{TimeLow, "time_low", "6ba7b810", u[0:4]},
+		{TimeMid, "time_mid", "9dad", u[4:6]},
+		{TimeHiAndVersion, "time_hi_and_version", "11d1", u[6:8]},
+		{ClockSeq, "clock_seq", "80b4", u[8:10]},
+		{Node, "node", "00c04fd430c8", u[10:16]},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.field.String())
+		assert.Equal(t, tt.text, string(Text([]byte(canonical), tt.field)))
+		assert.Equal(t, tt.bytes, Bytes(u, tt.field))
+	}
+	assert.Equal(t, "Field(5)", Field(5).String())
+}
+
+func TestIsDash(t *testing.T) {
+	for i := 0; i < CanonicalLen; i++ {
+		assert.Equal(t, canonical[i] == '-', IsDash(i), i)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// MaxV7NodeBits is the maximal width of node ID embedded into
+// version 7 UUIDs by WithV7NodeBits.
+const MaxV7NodeBits = 16
+
+// WithV7NodeBits makes generator store id of a worker or node in the low
+// width bits of rand_b field of version 7 UUIDs, in place of random bits,
+// similarly to Snowflake IDs. Then UUIDs generated by distinct nodes
+// never collide, even if their random sources are degraded. Width is
+// capped at MaxV7NodeBits and only its low width bits of id are used.
+// Use V7NodeID to extract node ID.
+func WithV7NodeBits(id uint16, width int) GeneratorOption {
+	width = min(max(width, 0), MaxV7NodeBits)
+	return func(g *rfc4122Generator) {
+		g.v7NodeBits = width
+		g.v7NodeID = id & v7NodeMask(width)
+	}
+}
+
+// V7NodeID returns node ID stored in the low width bits of version 7
+// UUID generated with WithV7NodeBits option.
+// It will return error if UUID isn't of version 7.
+func V7NodeID(u UUID, width int) (uint16, error) {
+	if u.Version() != V7 {
+		return 0, fmt.Errorf("uuid: expected version %d, got version %d", V7, u.Version())
+	}
+	if width < 0 || width > MaxV7NodeBits {
+		return 0, fmt.Errorf("uuid: node ID width %d out of range [0, %d]", width, MaxV7NodeBits)
+	}
+	return binary.BigEndian.Uint16(u[14:]) & v7NodeMask(width), nil
+}
+
+// Stores configured node ID in the low bits of UUID.
+func (g *rfc4122Generator) putV7NodeID(u *UUID) {
+	if g.v7NodeBits == 0 {
+		return
+	}
+	mask := v7NodeMask(g.v7NodeBits)
+	low := binary.BigEndian.Uint16(u[14:])
+	binary.BigEndian.PutUint16(u[14:], low&^mask|g.v7NodeID)
+}
+
+// Returns mask of the low width bits.
+func v7NodeMask(width int) uint16 {
+	return uint16(1<<width - 1)
+}
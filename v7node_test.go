@@ -0,0 +1,85 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestWithV7NodeBits(t *testing.T) {
+	g := NewGenerator(WithV7NodeBits(0x2a5, 10), WithRandReader(constReader(0xff)))
+	u, err := g.NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, V7, u.Version())
+	assert.Equal(t, VariantRFC4122, u.Variant())
+	assert.Equal(t, []byte{0xfe, 0xa5}, u[14:])
+
+	id, err := V7NodeID(u, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x2a5), id)
+
+	u, err = g.(ContextGenerator).NewV7Context(context.Background())
+	require.NoError(t, err)
+	id, err = V7NodeID(u, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x2a5), id)
+
+	us, err := g.(BatchGenerator).ReserveV7(3)
+	require.NoError(t, err)
+	for _, u := range us {
+		id, err = V7NodeID(u, 10)
+		require.NoError(t, err)
+		assert.Equal(t, uint16(0x2a5), id)
+	}
+}
+
+func TestWithV7NodeBitsWidth(t *testing.T) {
+	g := NewGenerator(WithV7NodeBits(0xffff, 4), WithRandReader(constReader(0x00)))
+	u, err := g.NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x0f}, u[14:])
+
+	g = NewGenerator(WithV7NodeBits(0xbeef, 100), WithRandReader(constReader(0x00)))
+	u, err = g.NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xbe, 0xef}, u[14:])
+
+	g = NewGenerator(WithV7NodeBits(0xbeef, 0), WithRandReader(constReader(0x00)))
+	u, err = g.NewV7()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00, 0x00}, u[14:])
+}
+
+func TestV7NodeIDInvalid(t *testing.T) {
+	_, err := V7NodeID(NamespaceDNS, 8)
+	assert.Error(t, err)
+
+	u := Must(NewV7())
+	_, err = V7NodeID(u, 17)
+	assert.Error(t, err)
+	_, err = V7NodeID(u, -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// V4UUID is a UUID pinned to version 4. Scanning or unmarshaling
+// a UUID of any other version into it fails, which lets data models
+// enforce the version of their identifiers at the type level.
+type V4UUID UUID
+
+// V7UUID is a UUID pinned to version 7. Scanning or unmarshaling
+// a UUID of any other version into it fails, which lets data models
+// enforce the version of their identifiers at the type level.
+type V7UUID UUID
+
+// Returns error if u isn't of version v.
+func checkVersion(u UUID, v byte) error {
+	if u.Version() != v {
+		return fmt.Errorf("uuid: expected version %d, got version %d", v, u.Version())
+	}
+	return nil
+}
+
+// Scans src into dst, checking its version.
+// dst is left intact on error.
+func scanVersion(dst *UUID, src interface{}, v byte) error {
+	var u UUID
+	if err := u.Scan(src); err != nil {
+		return err
+	}
+	if err := checkVersion(u, v); err != nil {
+		return err
+	}
+	*dst = u
+	return nil
+}
+
+// Unmarshals text into dst, checking its version.
+// dst is left intact on error.
+func unmarshalVersion(dst *UUID, text []byte, v byte) error {
+	var u UUID
+	if err := u.UnmarshalText(text); err != nil {
+		return err
+	}
+	if err := checkVersion(u, v); err != nil {
+		return err
+	}
+	*dst = u
+	return nil
+}
+
+// AsV4 returns u as V4UUID.
+// It will return error if u isn't of version 4.
+func AsV4(u UUID) (V4UUID, error) {
+	return V4UUID(u), checkVersion(u, V4)
+}
+
+// UUID returns u as UUID.
+func (u V4UUID) UUID() UUID {
+	return UUID(u)
+}
+
+// String returns canonical string representation of UUID.
+func (u V4UUID) String() string {
+	return UUID(u).String()
+}
+
+// Value implements the driver.Valuer interface.
+func (u V4UUID) Value() (driver.Value, error) {
+	return UUID(u).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as UUID.Scan, but only of version 4.
+func (u *V4UUID) Scan(src interface{}) error {
+	return scanVersion((*UUID)(u), src, V4)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u V4UUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText, but only of version 4.
+func (u *V4UUID) UnmarshalText(text []byte) error {
+	return unmarshalVersion((*UUID)(u), text, V4)
+}
+
+// AsV7 returns u as V7UUID.
+// It will return error if u isn't of version 7.
+func AsV7(u UUID) (V7UUID, error) {
+	return V7UUID(u), checkVersion(u, V7)
+}
+
+// UUID returns u as UUID.
+func (u V7UUID) UUID() UUID {
+	return UUID(u)
+}
+
+// String returns canonical string representation of UUID.
+func (u V7UUID) String() string {
+	return UUID(u).String()
+}
+
+// Value implements the driver.Valuer interface.
+func (u V7UUID) Value() (driver.Value, error) {
+	return UUID(u).Value()
+}
+
+// Scan implements the sql.Scanner interface.
+// It accepts the same inputs as UUID.Scan, but only of version 7.
+func (u *V7UUID) Scan(src interface{}) error {
+	return scanVersion((*UUID)(u), src, V7)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u V7UUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the same inputs as UUID.UnmarshalText, but only of version 7.
+func (u *V7UUID) UnmarshalText(text []byte) error {
+	return unmarshalVersion((*UUID)(u), text, V7)
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2013-2018 by Maxim Bublis <b@codemonkey.ru>
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/satori/go.uuid/internal/assert"
+	"github.com/satori/go.uuid/internal/require"
+)
+
+func TestV4UUID(t *testing.T) {
+	v4 := Must(NewV4())
+	v7 := Must(NewV7())
+
+	u, err := AsV4(v4)
+	require.NoError(t, err)
+	assert.Equal(t, v4, u.UUID())
+	assert.Equal(t, v4.String(), u.String())
+
+	_, err = AsV4(v7)
+	assert.Error(t, err)
+
+	val, err := u.Value()
+	require.NoError(t, err)
+	var u2 V4UUID
+	require.NoError(t, u2.Scan(val))
+	assert.Equal(t, u, u2)
+
+	err = u2.Scan(v7.String())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected version 4, got version 7")
+	assert.Equal(t, u, u2)
+
+	assert.Error(t, u2.Scan(Nil.Bytes()))
+	assert.Error(t, u2.Scan("invalid"))
+}
+
+func TestV7UUID(t *testing.T) {
+	v4 := Must(NewV4())
+	v7 := Must(NewV7())
+
+	u, err := AsV7(v7)
+	require.NoError(t, err)
+	assert.Equal(t, v7, u.UUID())
+
+	_, err = AsV7(v4)
+	assert.Error(t, err)
+
+	var u2 V7UUID
+	require.NoError(t, u2.Scan(v7.Bytes()))
+	assert.Equal(t, u, u2)
+	assert.Error(t, u2.Scan(v4.Bytes()))
+}
+
+func TestVersionedJSON(t *testing.T) {
+	type model struct {
+		ID    V7UUID
+		Token V4UUID
+	}
+	m := model{ID: V7UUID(Must(NewV7())), Token: V4UUID(Must(NewV4()))}
+
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+
+	var m2 model
+	require.NoError(t, json.Unmarshal(data, &m2))
+	assert.Equal(t, m, m2)
+
+	data, err = json.Marshal(model{ID: V7UUID(m.Token), Token: m.Token})
+	require.NoError(t, err)
+	assert.Error(t, json.Unmarshal(data, &m2))
+}